@@ -0,0 +1,60 @@
+package room
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	json "github.com/json-iterator/go"
+	"github.com/spf13/cobra"
+	"github.com/synctv-org/synctv/internal/bootstrap"
+	"github.com/synctv-org/synctv/internal/db"
+)
+
+var (
+	exportOutput  string
+	exportMembers bool
+)
+
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "export room with room id to a json bundle",
+	Long:  "export room with room id to a json bundle, the password and the vendor secrets are not exported",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return bootstrap.New(bootstrap.WithContext(cmd.Context())).Add(
+			bootstrap.InitDiscardLog,
+			bootstrap.InitConfig,
+			bootstrap.InitDatabase,
+		).Run()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("missing room id")
+		}
+		b, err := db.ExportRoom(args[0], exportMembers)
+		if err != nil {
+			fmt.Printf("export room failed: %s\n", err)
+			return nil
+		}
+		data, err := json.MarshalIndent(b, "", "  ")
+		if err != nil {
+			return err
+		}
+		if exportOutput == "" {
+			fmt.Println(string(data))
+			return nil
+		}
+		if err := os.WriteFile(exportOutput, data, 0o644); err != nil {
+			fmt.Printf("write bundle failed: %s\n", err)
+			return nil
+		}
+		fmt.Printf("export room success: %s\n", b.Room.Name)
+		return nil
+	},
+}
+
+func init() {
+	RoomCmd.AddCommand(ExportCmd)
+	ExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "output file, default stdout")
+	ExportCmd.Flags().BoolVar(&exportMembers, "members", false, "export the members with their roles and permissions")
+}
@@ -0,0 +1,88 @@
+package room
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	json "github.com/json-iterator/go"
+	"github.com/spf13/cobra"
+	"github.com/synctv-org/synctv/internal/bootstrap"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/op"
+)
+
+var (
+	importOwner    string
+	importName     string
+	importPassword string
+)
+
+var ImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import room from a json bundle",
+	Long:  "import room from a json bundle, the room gets new ids and is owned by the given user, the movies and members are given to the users with the same names",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return bootstrap.New(bootstrap.WithContext(cmd.Context())).Add(
+			bootstrap.InitDiscardLog,
+			bootstrap.InitConfig,
+			bootstrap.InitDatabase,
+			bootstrap.InitSetting,
+		).Run()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("missing bundle file")
+		}
+		if importOwner == "" {
+			return errors.New("missing owner")
+		}
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		b := &model.RoomBundle{}
+		if err := json.Unmarshal(data, b); err != nil {
+			fmt.Printf("parse bundle failed: %s\n", err)
+			return nil
+		}
+		if err := op.ValidateBundle(b); err != nil {
+			fmt.Printf("invalid bundle: %s\n", err)
+			return nil
+		}
+		owner, err := db.GetUserByUsername(importOwner)
+		if err != nil {
+			fmt.Printf("get owner failed: %s\n", err)
+			return nil
+		}
+		conf, err := db.WithBundle(b, owner, true)
+		if err != nil {
+			fmt.Printf("import room failed: %s\n", err)
+			return nil
+		}
+		name := importName
+		if name == "" {
+			name = b.Room.Name
+		}
+		r, err := db.CreateRoom(
+			name,
+			importPassword,
+			0,
+			append(conf, db.WithCreator(owner), db.WithStatus(model.RoomStatusActive))...,
+		)
+		if err != nil {
+			fmt.Printf("import room failed: %s\n", err)
+			return nil
+		}
+		fmt.Printf("import room success: %s %s\n", r.ID, r.Name)
+		return nil
+	},
+}
+
+func init() {
+	RoomCmd.AddCommand(ImportCmd)
+	ImportCmd.Flags().StringVar(&importOwner, "owner", "", "username of the owner of the imported room")
+	ImportCmd.Flags().StringVar(&importName, "name", "", "room name, default the name in the bundle")
+	ImportCmd.Flags().StringVar(&importPassword, "password", "", "room password")
+}
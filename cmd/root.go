@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/synctv-org/synctv/cmd/admin"
 	"github.com/synctv-org/synctv/cmd/flags"
+	"github.com/synctv-org/synctv/cmd/room"
 	"github.com/synctv-org/synctv/cmd/root"
 	"github.com/synctv-org/synctv/cmd/setting"
 	"github.com/synctv-org/synctv/cmd/user"
@@ -78,4 +79,5 @@ func init() {
 	RootCmd.AddCommand(user.UserCmd)
 	RootCmd.AddCommand(setting.SettingCmd)
 	RootCmd.AddCommand(root.RootCmd)
+	RootCmd.AddCommand(room.RoomCmd)
 }
@@ -2,11 +2,55 @@ package bootstrap
 
 import (
 	"context"
+	"fmt"
 
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/broadcaster"
+	"github.com/synctv-org/synctv/internal/conf"
 	"github.com/synctv-org/synctv/internal/op"
+	sysnotify "github.com/synctv-org/synctv/internal/sysNotify"
 )
 
 func InitOp(ctx context.Context) error {
+	b, err := newBroadcaster(conf.Conf.Broadcaster)
+	if err != nil {
+		log.Fatalf("failed to init broadcaster: %s", err.Error())
+	}
+	err = sysnotify.RegisterSysNotifyTask(0, sysnotify.NewSysNotifyTask("broadcaster", sysnotify.NotifyTypeEXIT, b.Close))
+	if err != nil {
+		log.Fatalf("failed to register sysnotify task: %s", err.Error())
+	}
+	op.SetBroadcaster(b)
+	err = op.SetSlowConsumerPolicy(op.SlowConsumerPolicy(conf.Conf.WebSocket.SlowConsumerPolicy), conf.Conf.WebSocket.MaxDropped)
+	if err != nil {
+		log.Fatalf("failed to set slow consumer policy: %s", err.Error())
+	}
 	op.Init(4096)
+	// before the database is closed
+	err = sysnotify.RegisterSysNotifyTask(-1, sysnotify.NewSysNotifyTask("playback", sysnotify.NotifyTypeEXIT, op.FlushPlaybacks))
+	if err != nil {
+		log.Fatalf("failed to register sysnotify task: %s", err.Error())
+	}
 	return nil
 }
+
+func newBroadcaster(c conf.BroadcasterConfig) (broadcaster.Broadcaster, error) {
+	switch c.Type {
+	case "", "local":
+		return broadcaster.NewLocal(), nil
+	case "etcd":
+		b, err := broadcaster.NewEtcd(broadcaster.EtcdConfig{
+			Endpoints: c.Etcd.Endpoints,
+			Username:  c.Etcd.Username,
+			Password:  c.Etcd.Password,
+			Prefix:    c.Etcd.Prefix,
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("broadcaster etcd: %v", c.Etcd.Endpoints)
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unknown broadcaster type: %s", c.Type)
+	}
+}
@@ -31,7 +31,17 @@ func auth(ReqAppName, ReqChannelName string, IsPublisher bool) (*rtmps.Channel,
 			log.Errorf("rtmp: get room by id error: %v", err)
 			return nil, err
 		}
-		return r.Value().GetChannel(channelName)
+		c, err := r.Value().GetChannel(channelName)
+		if err != nil {
+			return nil, err
+		}
+		// a second publisher would fail to push, the stream has not started again
+		if c.InPublication() {
+			log.Errorf("rtmp: publish to %s/%s error: %v", ReqAppName, channelName, rtmps.ErrPusherAlreadyInPublication)
+			return nil, rtmps.ErrPusherAlreadyInPublication
+		}
+		r.Value().LivePublishStarted(channelName)
+		return c, nil
 	}
 
 	if !settings.RtmpPlayer.Get() {
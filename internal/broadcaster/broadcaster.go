@@ -0,0 +1,68 @@
+package broadcaster
+
+import (
+	"context"
+)
+
+type EventType uint8
+
+const (
+	EventBroadcast EventType = iota + 1
+	EventSendToUser
+	EventKickUser
+	EventPeopleNum
+	// a vote for the poll running on another node
+	EventPollVote
+	// cancels the poll running on another node
+	EventPollCancel
+	// drops the room from the cache of the node, published to the control channel
+	EventEvictRoom
+	// drops the cached roles of the room, published to the control channel
+	EventInvalidateRoomRoles
+	// the current movie or its status changed, Data is the new state,
+	// published to the control channel
+	EventSyncRoomCurrent
+	// reloads the movies of the room, published to the control channel
+	EventInvalidateRoomMovies
+	// reloads the settings of the room, published to the control channel
+	EventInvalidateRoomSettings
+	// drops the cached member UserID of the room, published to the control channel
+	EventInvalidateRoomMember
+	// UserID sent a chat message, the slow mode of the other nodes starts from it
+	EventChatSent
+)
+
+// Event is a hub operation propagated to the other synctv nodes.
+type Event struct {
+	Node      string    `json:"node"`
+	Type      EventType `json:"type"`
+	UserID    string    `json:"userId,omitempty"`
+	IgnoreIDs []string  `json:"ignoreIds,omitempty"`
+	PeopleNum int64     `json:"peopleNum,omitempty"`
+	// the users online on the node, reported with the people number
+	UserIDs []string `json:"userIds,omitempty"`
+	// the host of the room known by the node and when it was set in unix milliseconds,
+	// reported with the people number so the nodes agree on the latest one
+	HostID string `json:"hostId,omitempty"`
+	HostAt int64  `json:"hostAt,omitempty"`
+	// the poll voted or canceled and the option voted,
+	// only the node running the poll handles them
+	PollID     string `json:"pollId,omitempty"`
+	PollOption int    `json:"pollOption,omitempty"`
+	// the room of an event published to the control channel
+	RoomID string `json:"roomId,omitempty"`
+	Data   []byte `json:"data,omitempty"`
+}
+
+type Handler func(e *Event)
+
+type Broadcaster interface {
+	// NodeID returns the id of the current node
+	NodeID() string
+	// Publish sends the event to every other node subscribed to the room
+	Publish(ctx context.Context, roomID string, e *Event) error
+	// Subscribe receives events published by other nodes for the room,
+	// events published by the current node are never delivered
+	Subscribe(roomID string, handler Handler) (unsubscribe func(), err error)
+	Close() error
+}
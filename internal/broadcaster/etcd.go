@@ -0,0 +1,138 @@
+package broadcaster
+
+import (
+	"context"
+	"errors"
+	"path"
+	"time"
+
+	json "github.com/json-iterator/go"
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/utils"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+var _ Broadcaster = (*etcdBroadcaster)(nil)
+
+// every node writes the events of a room to its own key,
+// so the number of keys is bounded by rooms * nodes,
+// and the keys are removed with the lease when the node goes away.
+type etcdBroadcaster struct {
+	node   string
+	prefix string
+	cli    *clientv3.Client
+	lease  clientv3.LeaseID
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type EtcdConfig struct {
+	Endpoints []string
+	Username  string
+	Password  string
+	Prefix    string
+}
+
+const etcdLeaseTTL = 30
+
+func NewEtcd(conf EtcdConfig) (Broadcaster, error) {
+	if len(conf.Endpoints) == 0 {
+		return nil, errors.New("etcd endpoints is empty")
+	}
+	if conf.Prefix == "" {
+		conf.Prefix = "/synctv"
+	}
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   conf.Endpoints,
+		Username:    conf.Username,
+		Password:    conf.Password,
+		DialTimeout: time.Second * 5,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	grantCtx, grantCancel := context.WithTimeout(ctx, time.Second*5)
+	defer grantCancel()
+	lease, err := cli.Grant(grantCtx, etcdLeaseTTL)
+	if err != nil {
+		cancel()
+		cli.Close()
+		return nil, err
+	}
+	ch, err := cli.KeepAlive(ctx, lease.ID)
+	if err != nil {
+		cancel()
+		cli.Close()
+		return nil, err
+	}
+	go func() {
+		for range ch {
+		}
+		if ctx.Err() == nil {
+			log.Error("broadcaster: etcd lease keepalive stopped")
+		}
+	}()
+	return &etcdBroadcaster{
+		node:   utils.SortUUID(),
+		prefix: conf.Prefix,
+		cli:    cli,
+		lease:  lease.ID,
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+func (e *etcdBroadcaster) NodeID() string {
+	return e.node
+}
+
+func (e *etcdBroadcaster) roomPrefix(roomID string) string {
+	return path.Join(e.prefix, "rooms", roomID) + "/"
+}
+
+func (e *etcdBroadcaster) Publish(ctx context.Context, roomID string, ev *Event) error {
+	ev.Node = e.node
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = e.cli.Put(ctx, e.roomPrefix(roomID)+e.node, string(data), clientv3.WithLease(e.lease))
+	return err
+}
+
+func (e *etcdBroadcaster) Subscribe(roomID string, handler Handler) (func(), error) {
+	if e.ctx.Err() != nil {
+		return nil, errors.New("broadcaster closed")
+	}
+	ctx, cancel := context.WithCancel(e.ctx)
+	wch := e.cli.Watch(ctx, e.roomPrefix(roomID), clientv3.WithPrefix(), clientv3.WithFilterDelete())
+	go func() {
+		for resp := range wch {
+			if err := resp.Err(); err != nil {
+				log.Errorf("broadcaster: watch room %s error: %v", roomID, err)
+				continue
+			}
+			for _, ev := range resp.Events {
+				var event Event
+				if err := json.Unmarshal(ev.Kv.Value, &event); err != nil {
+					log.Errorf("broadcaster: unmarshal event error: %v", err)
+					continue
+				}
+				if event.Node == e.node {
+					continue
+				}
+				handler(&event)
+			}
+		}
+	}()
+	return cancel, nil
+}
+
+func (e *etcdBroadcaster) Close() error {
+	e.cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	_, _ = e.cli.Revoke(ctx, e.lease)
+	return e.cli.Close()
+}
@@ -0,0 +1,37 @@
+package broadcaster
+
+import (
+	"context"
+
+	"github.com/synctv-org/synctv/utils"
+)
+
+var _ Broadcaster = (*local)(nil)
+
+// local is used when synctv runs as a single node,
+// all clients of a room are in the same hub so there is nothing to propagate.
+type local struct {
+	node string
+}
+
+func NewLocal() Broadcaster {
+	return &local{
+		node: utils.SortUUID(),
+	}
+}
+
+func (l *local) NodeID() string {
+	return l.node
+}
+
+func (l *local) Publish(ctx context.Context, roomID string, e *Event) error {
+	return nil
+}
+
+func (l *local) Subscribe(roomID string, handler Handler) (func(), error) {
+	return func() {}, nil
+}
+
+func (l *local) Close() error {
+	return nil
+}
@@ -0,0 +1,22 @@
+package conf
+
+type BroadcasterConfig struct {
+	Type string                `yaml:"type" lc:"default: local" hc:"support local, etcd. use etcd to share room messages between multiple synctv nodes" env:"BROADCASTER_TYPE"`
+	Etcd EtcdBroadcasterConfig `yaml:"etcd"`
+}
+
+type EtcdBroadcasterConfig struct {
+	Endpoints []string `yaml:"endpoints" env:"BROADCASTER_ETCD_ENDPOINTS"`
+	Username  string   `yaml:"username" env:"BROADCASTER_ETCD_USERNAME"`
+	Password  string   `yaml:"password" env:"BROADCASTER_ETCD_PASSWORD"`
+	Prefix    string   `yaml:"prefix" lc:"default: /synctv" env:"BROADCASTER_ETCD_PREFIX"`
+}
+
+func DefaultBroadcasterConfig() BroadcasterConfig {
+	return BroadcasterConfig{
+		Type: "local",
+		Etcd: EtcdBroadcasterConfig{
+			Prefix: "/synctv",
+		},
+	}
+}
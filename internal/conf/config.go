@@ -22,6 +22,12 @@ type Config struct {
 
 	// RateLimit
 	RateLimit RateLimitConfig `yaml:"rate_limit"`
+
+	// Broadcaster
+	Broadcaster BroadcasterConfig `yaml:"broadcaster"`
+
+	// WebSocket
+	WebSocket WebSocketConfig `yaml:"websocket"`
 }
 
 func (c *Config) Save(file string) error {
@@ -47,5 +53,11 @@ func DefaultConfig() *Config {
 
 		// RateLimit
 		RateLimit: DefaultRateLimitConfig(),
+
+		// Broadcaster
+		Broadcaster: DefaultBroadcasterConfig(),
+
+		// WebSocket
+		WebSocket: DefaultWebSocketConfig(),
 	}
 }
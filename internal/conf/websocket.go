@@ -0,0 +1,13 @@
+package conf
+
+type WebSocketConfig struct {
+	SlowConsumerPolicy string `yaml:"slow_consumer_policy" lc:"default: drop_oldest" hc:"support drop_oldest, coalesce, disconnect. how to handle a client that can not keep up with the room messages, a client may ask for its own by the slow_consumer query" env:"WEBSOCKET_SLOW_CONSUMER_POLICY"`
+	MaxDropped         uint64 `yaml:"max_dropped" lc:"default: 64" hc:"disconnect policy disconnects the client after dropping this many messages, a client may ask for its own by the max_dropped query" env:"WEBSOCKET_MAX_DROPPED"`
+}
+
+func DefaultWebSocketConfig() WebSocketConfig {
+	return WebSocketConfig{
+		SlowConsumerPolicy: "drop_oldest",
+		MaxDropped:         64,
+	}
+}
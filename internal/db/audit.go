@@ -0,0 +1,29 @@
+package db
+
+import (
+	"github.com/synctv-org/synctv/internal/model"
+	"gorm.io/gorm"
+)
+
+func CreateRoomAuditLog(l *model.RoomAuditLog) error {
+	return db.Create(l).Error
+}
+
+// GetRoomAuditLogs returns the entries of the room matching the scopes, newest first
+func GetRoomAuditLogs(roomID string, page, pageSize int, scopes ...func(*gorm.DB) *gorm.DB) ([]*model.RoomAuditLog, int64, error) {
+	var total int64
+	err := db.Model(&model.RoomAuditLog{}).
+		Where("room_id = ?", roomID).
+		Scopes(scopes...).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	list := []*model.RoomAuditLog{}
+	err = db.Where("room_id = ?", roomID).
+		Scopes(scopes...).
+		Order("id desc").
+		Scopes(Paginate(page, pageSize)).
+		Find(&list).Error
+	return list, total, err
+}
@@ -0,0 +1,193 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/synctv-org/synctv/internal/model"
+)
+
+// ExportRoom bundles the room with its settings and playlist, and the members if withMembers
+func ExportRoom(roomID string, withMembers bool) (*model.RoomBundle, error) {
+	r, err := GetRoomByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := GetOrCreateRoomSettings(roomID)
+	if err != nil {
+		return nil, err
+	}
+	s.ID = ""
+	movies := GetAllMoviesByRoomID(roomID)
+	var members []*model.RoomMember
+	if withMembers {
+		err = db.Where("room_id = ? AND role <> ?", roomID, model.RoomMemberRoleCreator).
+			Order("created_at asc").
+			Find(&members).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ids := []string{r.CreatorID}
+	for _, m := range movies {
+		ids = append(ids, m.CreatorID)
+	}
+	for _, m := range members {
+		ids = append(ids, m.UserID)
+	}
+	names, err := userNamesByID(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &model.RoomBundle{
+		Version:    model.RoomBundleVersion,
+		ExportedAt: time.Now(),
+		Room: model.RoomBundleRoom{
+			Name:        r.Name,
+			CreatorName: names[r.CreatorID],
+			Settings:    s,
+		},
+		Movies: make([]*model.RoomBundleMovie, len(movies)),
+	}
+	for i, m := range movies {
+		if m.Base.VendorInfo.Alist != nil {
+			// the key of the password is derived from the path only, so it cannot be re-encrypted
+			m.Base.VendorInfo.Alist.Password = ""
+		}
+		// the headers may carry the credentials of the source
+		m.Base.Headers = nil
+		b.Movies[i] = &model.RoomBundleMovie{
+			CreatorName: names[m.CreatorID],
+			Base:        m.Base,
+		}
+	}
+	for _, m := range members {
+		name, ok := names[m.UserID]
+		if !ok {
+			continue
+		}
+		b.Members = append(b.Members, &model.RoomBundleMember{
+			Username:         name,
+			Status:           m.Status,
+			Role:             m.Role,
+			Permissions:      m.Permissions,
+			AdminPermissions: m.AdminPermissions,
+		})
+	}
+	return b, nil
+}
+
+// WithBundle returns the configs creating the room from the bundle.
+//
+// With remapUsers, for an import by the operator, the movies and members are given to the users
+// with the same names on this instance, the movies of unknown users to the owner and the unknown
+// members are skipped. Otherwise every movie is given to the owner and the members are dropped,
+// so a user importing a bundle can not act in the name of others.
+// The vendor movies their new creator can not play are skipped.
+func WithBundle(b *model.RoomBundle, owner *model.User, remapUsers bool) ([]CreateRoomConfig, error) {
+	if b.Version > model.RoomBundleVersion {
+		return nil, fmt.Errorf("unsupported room bundle version: %d", b.Version)
+	}
+	ids := map[string]string{}
+	if remapUsers {
+		var names []string
+		for _, m := range b.Movies {
+			names = append(names, m.CreatorName)
+		}
+		for _, m := range b.Members {
+			names = append(names, m.Username)
+		}
+		var err error
+		ids, err = userIDsByName(names)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	settings := model.DefaultRoomSettings()
+	if b.Room.Settings != nil {
+		settings = b.Room.Settings
+		settings.ID = ""
+	}
+	conf := []CreateRoomConfig{WithSetting(settings)}
+
+	if len(b.Movies) != 0 {
+		movies := make([]*model.Movie, len(b.Movies))
+		position := uint(time.Now().UnixMilli())
+		for i, m := range b.Movies {
+			creatorID, ok := ids[m.CreatorName]
+			if !ok {
+				creatorID = owner.ID
+			}
+			movies[i] = &model.Movie{
+				Position:  position + uint(i),
+				CreatorID: creatorID,
+				Base:      m.Base,
+			}
+		}
+		movies, err := playableVendorMovies(movies)
+		if err != nil {
+			return nil, err
+		}
+		conf = append(conf, withMovies(movies))
+	}
+
+	if !remapUsers {
+		return conf, nil
+	}
+	var members []*model.RoomMember
+	for _, m := range b.Members {
+		userID, ok := ids[m.Username]
+		if !ok || userID == owner.ID || m.Role == model.RoomMemberRoleCreator {
+			continue
+		}
+		members = append(members, &model.RoomMember{
+			UserID:           userID,
+			Status:           m.Status,
+			Role:             m.Role,
+			Permissions:      m.Permissions,
+			AdminPermissions: m.AdminPermissions,
+		})
+	}
+	if len(members) != 0 {
+		conf = append(conf, WithRelations(members))
+	}
+	return conf, nil
+}
+
+func withMovies(movies []*model.Movie) CreateRoomConfig {
+	return func(r *model.Room) {
+		r.Movies = movies
+	}
+}
+
+func userNamesByID(ids []string) (map[string]string, error) {
+	users := []*model.User{}
+	err := db.Select("id", "username").Where("id IN ?", ids).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]string, len(users))
+	for _, u := range users {
+		m[u.ID] = u.Username
+	}
+	return m, nil
+}
+
+func userIDsByName(names []string) (map[string]string, error) {
+	m := map[string]string{}
+	if len(names) == 0 {
+		return m, nil
+	}
+	users := []*model.User{}
+	err := db.Select("id", "username").Where("username IN ?", names).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		m[u.Username] = u.ID
+	}
+	return m, nil
+}
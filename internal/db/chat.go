@@ -0,0 +1,64 @@
+package db
+
+import (
+	"time"
+
+	"github.com/synctv-org/synctv/internal/model"
+	"gorm.io/gorm"
+)
+
+func CreateChatMessage(msg *model.ChatMessage) error {
+	return db.Create(msg).Error
+}
+
+func GetChatMessage(roomID string, id uint64) (*model.ChatMessage, error) {
+	msg := &model.ChatMessage{}
+	err := db.Where("room_id = ? AND id = ?", roomID, id).First(msg).Error
+	return msg, HandleNotFound(err, "chat message")
+}
+
+func DeleteChatMessage(roomID string, id uint64) error {
+	err := db.Where("room_id = ? AND id = ?", roomID, id).Delete(&model.ChatMessage{}).Error
+	return HandleNotFound(err, "chat message")
+}
+
+// if beforeID is 0, it will return the latest messages
+func GetChatMessagesBefore(roomID string, beforeID uint64, limit int, scopes ...func(*gorm.DB) *gorm.DB) ([]*model.ChatMessage, error) {
+	msgs := []*model.ChatMessage{}
+	tx := db.Where("room_id = ?", roomID)
+	if beforeID != 0 {
+		tx = tx.Where("id < ?", beforeID)
+	}
+	err := tx.Scopes(scopes...).Order("id desc").Limit(limit).Find(&msgs).Error
+	return msgs, err
+}
+
+// the messages sent to the whole room and the whispers the user sent or received
+func WhereChatVisibleTo(userID string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("receiver_id = ? OR receiver_id = ? OR sender_id = ?", "", userID, userID)
+	}
+}
+
+// delete the messages older than the chat history retention of their room,
+// rooms with zero retention keep the history forever
+func DeleteExpiredChatMessages() error {
+	var retentions []int64
+	err := db.Model(&model.RoomSettings{}).
+		Where("chat_history_retention_days > ?", 0).
+		Distinct().
+		Pluck("chat_history_retention_days", &retentions).Error
+	if err != nil {
+		return err
+	}
+	for _, days := range retentions {
+		err = db.
+			Where("created_at < ?", time.Now().AddDate(0, 0, -int(days))).
+			Where("room_id IN (?)", db.Model(&model.RoomSettings{}).Select("id").Where("chat_history_retention_days = ?", days)).
+			Delete(&model.ChatMessage{}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"github.com/synctv-org/synctv/internal/model"
+	"gorm.io/gorm"
+)
+
+func CreateDanmaku(d *model.Danmaku) error {
+	return db.Create(d).Error
+}
+
+// the danmaku of the movie between start and end seconds, ordered by time
+func GetDanmakuInRange(movieID string, start, end float64, limit int) ([]*model.Danmaku, error) {
+	list := []*model.Danmaku{}
+	err := db.
+		Where("movie_id = ? AND time >= ? AND time < ?", movieID, start, end).
+		Order("time asc").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// ReplaceImportedDanmaku replaces the danmaku imported from the source of the movie before
+func ReplaceImportedDanmaku(movieID string, list []*model.Danmaku) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("movie_id = ? AND sender_id = ?", movieID, "").Delete(&model.Danmaku{}).Error
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(list, 500).Error
+	})
+}
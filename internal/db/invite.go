@@ -0,0 +1,57 @@
+package db
+
+import (
+	"time"
+
+	"github.com/synctv-org/synctv/internal/model"
+)
+
+func CreateRoomInvite(i *model.RoomInvite) error {
+	return db.Create(i).Error
+}
+
+func GetRoomInvites(roomID string) ([]*model.RoomInvite, error) {
+	list := []*model.RoomInvite{}
+	err := db.Where("room_id = ?", roomID).Order("created_at desc").Find(&list).Error
+	return list, err
+}
+
+func GetRoomInviteByToken(token string) (*model.RoomInvite, error) {
+	i := &model.RoomInvite{}
+	err := db.Where("token = ?", token).First(i).Error
+	return i, HandleNotFound(err, "invite")
+}
+
+// UseRoomInvite takes one use of the invite, it returns false if the invite
+// has expired or has been used up meanwhile
+func UseRoomInvite(id string) (bool, error) {
+	result := db.Model(&model.RoomInvite{}).
+		Where("id = ? AND uses < max_uses AND expires_at > ?", id, time.Now()).
+		Update("uses", db.Raw("uses + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func DeleteRoomInvite(roomID, id string) error {
+	result := db.Where("room_id = ? AND id = ?", roomID, id).Delete(&model.RoomInvite{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound("invite")
+	}
+	return nil
+}
+
+// RoomActivateInvitedMember turns a pending member into an active one with the permissions of the invite
+func RoomActivateInvitedMember(roomID, userID string, permissions model.RoomMemberPermission) error {
+	err := db.Model(&model.RoomMember{}).
+		Where("room_id = ? AND user_id = ? AND status = ?", roomID, userID, model.RoomMemberStatusPending).
+		Updates(map[string]any{
+			"status":      model.RoomMemberStatusActive,
+			"permissions": permissions,
+		}).Error
+	return HandleNotFound(err, "room or user")
+}
@@ -33,7 +33,8 @@ func WithRoomMemberAdminPermissions(permissions model.RoomAdminPermission) Creat
 	}
 }
 
-func FirstOrCreateRoomMemberRelation(roomID, userID string, conf ...CreateRoomMemberRelationConfig) (*model.RoomMember, error) {
+// FirstOrCreateRoomMemberRelation also reports whether the member was created by this call
+func FirstOrCreateRoomMemberRelation(roomID, userID string, conf ...CreateRoomMemberRelationConfig) (*model.RoomMember, bool, error) {
 	roomMemberRelation := &model.RoomMember{}
 	d := &model.RoomMember{
 		RoomID:           roomID,
@@ -46,8 +47,8 @@ func FirstOrCreateRoomMemberRelation(roomID, userID string, conf ...CreateRoomMe
 	for _, c := range conf {
 		c(d)
 	}
-	err := db.Where("room_id = ? AND user_id = ?", roomID, userID).Attrs(d).FirstOrCreate(roomMemberRelation).Error
-	return roomMemberRelation, err
+	result := db.Where("room_id = ? AND user_id = ?", roomID, userID).Attrs(d).FirstOrCreate(roomMemberRelation)
+	return roomMemberRelation, result.RowsAffected != 0, result.Error
 }
 
 func GetRoomMember(roomID, userID string) (*model.RoomMember, error) {
@@ -75,12 +76,18 @@ func RoomApprovePendingMember(roomID, userID string) error {
 	return err
 }
 
+// RoomBanMember bans the member and removes its roles, an unban does not give them back
 func RoomBanMember(roomID, userID string) error {
-	err := db.Model(&model.RoomMember{}).
-		Where("room_id = ? AND user_id = ?", roomID, userID).
-		Update("status", model.RoomMemberStatusBanned).
-		Error
-	return HandleNotFound(err, "room or user")
+	return Transactional(func(tx *gorm.DB) error {
+		err := tx.Model(&model.RoomMember{}).
+			Where("room_id = ? AND user_id = ?", roomID, userID).
+			Update("status", model.RoomMemberStatusBanned).
+			Error
+		if err != nil {
+			return HandleNotFound(err, "room or user")
+		}
+		return tx.Where("room_id = ? AND user_id = ?", roomID, userID).Delete(&model.RoomRoleMember{}).Error
+	})
 }
 
 func RoomUnbanMember(roomID, userID string) error {
@@ -88,6 +95,11 @@ func RoomUnbanMember(roomID, userID string) error {
 	return HandleNotFound(err, "room or user")
 }
 
+func SetRoomMemberMutedUntil(roomID, userID string, until int64) error {
+	err := db.Model(&model.RoomMember{}).Where("room_id = ? AND user_id = ?", roomID, userID).Update("muted_until", until).Error
+	return HandleNotFound(err, "room or user")
+}
+
 func SetMemberPermissions(roomID string, userID string, permission model.RoomMemberPermission) error {
 	err := db.Model(&model.RoomMember{}).Where("room_id = ? AND user_id = ?", roomID, userID).Update("permissions", permission).Error
 	return HandleNotFound(err, "room or user")
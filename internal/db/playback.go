@@ -0,0 +1,23 @@
+package db
+
+import (
+	"github.com/synctv-org/synctv/internal/model"
+	"gorm.io/gorm"
+)
+
+func GetRoomPlayback(roomID string) (*model.RoomPlayback, error) {
+	p := &model.RoomPlayback{}
+	err := db.Where("room_id = ?", roomID).First(p).Error
+	return p, HandleNotFound(err, "room playback")
+}
+
+// SaveRoomPlayback does nothing but return not found if the room has been deleted
+func SaveRoomPlayback(p *model.RoomPlayback) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Select("id").Where("id = ?", p.RoomID).First(&model.Room{}).Error
+		if err != nil {
+			return HandleNotFound(err, "room")
+		}
+		return tx.Save(p).Error
+	})
+}
@@ -21,15 +21,13 @@ func WithSetting(setting *model.RoomSettings) CreateRoomConfig {
 func WithCreator(creator *model.User) CreateRoomConfig {
 	return func(r *model.Room) {
 		r.CreatorID = creator.ID
-		r.GroupUserRelations = []*model.RoomMember{
-			{
-				UserID:           creator.ID,
-				Status:           model.RoomMemberStatusActive,
-				Role:             model.RoomMemberRoleCreator,
-				Permissions:      model.AllPermissions,
-				AdminPermissions: model.AllAdminPermissions,
-			},
-		}
+		r.GroupUserRelations = append(r.GroupUserRelations, &model.RoomMember{
+			UserID:           creator.ID,
+			Status:           model.RoomMemberStatusActive,
+			Role:             model.RoomMemberRoleCreator,
+			Permissions:      model.AllPermissions,
+			AdminPermissions: model.AllAdminPermissions,
+		})
 	}
 }
 
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/synctv-org/synctv/internal/model"
+	"gorm.io/gorm"
+)
+
+// GetRoomRoles returns the roles of the room with their members
+func GetRoomRoles(roomID string) ([]*model.RoomRole, error) {
+	list := []*model.RoomRole{}
+	err := db.Where("room_id = ?", roomID).Preload("Members").Order("created_at asc").Find(&list).Error
+	return list, err
+}
+
+func GetRoomRole(roomID, id string) (*model.RoomRole, error) {
+	r := &model.RoomRole{}
+	err := db.Where("room_id = ? AND id = ?", roomID, id).First(r).Error
+	return r, HandleNotFound(err, "role")
+}
+
+func CreateRoomRole(r *model.RoomRole) error {
+	err := db.Create(r).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return errors.New("role name already exists")
+	}
+	return err
+}
+
+func UpdateRoomRole(r *model.RoomRole) error {
+	result := db.Model(&model.RoomRole{}).
+		Where("room_id = ? AND id = ?", r.RoomID, r.ID).
+		Updates(map[string]any{
+			"name":              r.Name,
+			"permissions":       r.Permissions,
+			"admin_permissions": r.AdminPermissions,
+		})
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return errors.New("role name already exists")
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound("role")
+	}
+	return nil
+}
+
+func DeleteRoomRole(roomID, id string) error {
+	result := db.Where("room_id = ? AND id = ?", roomID, id).Delete(&model.RoomRole{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound("role")
+	}
+	return nil
+}
+
+// SetRoomMemberRoles replaces the roles of the member, the roles must belong to the room
+func SetRoomMemberRoles(roomID, userID string, roleIDs []string) error {
+	return Transactional(func(tx *gorm.DB) error {
+		if len(roleIDs) != 0 {
+			var count int64
+			err := tx.Model(&model.RoomRole{}).
+				Where("room_id = ? AND id IN ?", roomID, roleIDs).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count != int64(len(roleIDs)) {
+				return ErrNotFound("role")
+			}
+		}
+		err := tx.Where("room_id = ? AND user_id = ?", roomID, userID).Delete(&model.RoomRoleMember{}).Error
+		if err != nil {
+			return err
+		}
+		if len(roleIDs) == 0 {
+			return nil
+		}
+		members := make([]*model.RoomRoleMember, len(roleIDs))
+		for i, id := range roleIDs {
+			members[i] = &model.RoomRoleMember{
+				RoleID: id,
+				UserID: userID,
+				RoomID: roomID,
+			}
+		}
+		return tx.Create(&members).Error
+	})
+}
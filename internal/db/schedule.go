@@ -0,0 +1,36 @@
+package db
+
+import (
+	"time"
+
+	"github.com/synctv-org/synctv/internal/model"
+)
+
+func CreateRoomSchedule(s *model.RoomSchedule) error {
+	return db.Create(s).Error
+}
+
+// the schedules of the room that have not started, ordered by start time
+func GetRoomSchedules(roomID string) ([]*model.RoomSchedule, error) {
+	list := []*model.RoomSchedule{}
+	err := db.Where("room_id = ?", roomID).Order("start_at asc").Find(&list).Error
+	return list, err
+}
+
+// the schedules of all rooms starting before t
+func GetSchedulesBefore(t time.Time) ([]*model.RoomSchedule, error) {
+	list := []*model.RoomSchedule{}
+	err := db.Where("start_at < ?", t).Order("start_at asc").Find(&list).Error
+	return list, err
+}
+
+func DeleteRoomSchedule(roomID, id string) error {
+	result := db.Where("room_id = ? AND id = ?", roomID, id).Delete(&model.RoomSchedule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound("schedule")
+	}
+	return nil
+}
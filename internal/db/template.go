@@ -0,0 +1,146 @@
+package db
+
+import (
+	"time"
+
+	"github.com/synctv-org/synctv/internal/model"
+	"gorm.io/gorm"
+)
+
+func CreateRoomTemplate(t *model.RoomTemplate) error {
+	return db.Create(t).Error
+}
+
+func preloadTemplateMovies(db *gorm.DB) *gorm.DB {
+	return db.Order("position asc")
+}
+
+func GetRoomTemplates(creatorID string) ([]*model.RoomTemplate, error) {
+	list := []*model.RoomTemplate{}
+	err := db.Preload("Movies", preloadTemplateMovies).
+		Where("creator_id = ?", creatorID).
+		Order("created_at desc").
+		Find(&list).Error
+	return list, err
+}
+
+func GetRoomTemplate(creatorID, id string) (*model.RoomTemplate, error) {
+	t := &model.RoomTemplate{}
+	err := db.Preload("Movies", preloadTemplateMovies).
+		Where("creator_id = ? AND id = ?", creatorID, id).
+		First(t).Error
+	return t, HandleNotFound(err, "template")
+}
+
+func DeleteRoomTemplate(creatorID, id string) error {
+	result := db.Where("creator_id = ? AND id = ?", creatorID, id).Delete(&model.RoomTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound("template")
+	}
+	return nil
+}
+
+// WithTemplate creates the room with the settings and movies of the template,
+// the movies are added by creatorID, the vendor movies creatorID can not play are skipped
+func WithTemplate(t *model.RoomTemplate, creatorID string) (CreateRoomConfig, error) {
+	settings := model.DefaultRoomSettings()
+	if t.Settings != nil {
+		s := *t.Settings
+		s.ID = ""
+		settings = &s
+	}
+	settings.UserDefaultPermissions = t.Permissions
+	movies := make([]*model.Movie, len(t.Movies))
+	position := uint(time.Now().UnixMilli())
+	for i, m := range t.Movies {
+		movies[i] = &model.Movie{
+			Position:  position + uint(i),
+			CreatorID: creatorID,
+			Base:      m.Base,
+		}
+	}
+	movies, err := playableVendorMovies(movies)
+	if err != nil {
+		return nil, err
+	}
+	return func(r *model.Room) {
+		r.Settings = settings
+		r.Movies = movies
+	}, nil
+}
+
+// WithClone creates the room with the settings and playlist of the room,
+// the movies are added by creatorID, the vendor movies creatorID can not play are skipped
+func WithClone(roomID, creatorID string) (CreateRoomConfig, error) {
+	settings, err := GetOrCreateRoomSettings(roomID)
+	if err != nil {
+		return nil, err
+	}
+	settings.ID = ""
+	movies := GetAllMoviesByRoomID(roomID)
+	position := uint(time.Now().UnixMilli())
+	for i, m := range movies {
+		movies[i] = &model.Movie{
+			Position:  position + uint(i),
+			CreatorID: creatorID,
+			Base:      m.Base,
+		}
+	}
+	movies, err = playableVendorMovies(movies)
+	if err != nil {
+		return nil, err
+	}
+	return func(r *model.Room) {
+		r.Settings = settings
+		r.Movies = movies
+	}, nil
+}
+
+// playableVendorMovies drops the alist and emby movies on a server their creator has not bound,
+// their path keeps the server of the user who added them, which the creator can not play.
+func playableVendorMovies(movies []*model.Movie) ([]*model.Movie, error) {
+	bound := map[string]bool{}
+	kept := movies[:0]
+	for _, m := range movies {
+		var (
+			serverID string
+			vendor   any
+			err      error
+		)
+		info := m.Base.VendorInfo
+		switch {
+		case info.Vendor == model.VendorAlist && info.Alist != nil:
+			serverID, _, err = model.GetAlistServerIdFromPath(info.Alist.Path)
+			vendor = &model.AlistVendor{}
+		case info.Vendor == model.VendorEmby && info.Emby != nil:
+			serverID, _, err = model.GetEmbyServerIdFromPath(info.Emby.Path)
+			vendor = &model.EmbyVendor{}
+		case info.Vendor == model.VendorAlist, info.Vendor == model.VendorEmby:
+			continue
+		default:
+			kept = append(kept, m)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		key := m.CreatorID + "/" + string(info.Vendor) + "/" + serverID
+		ok, checked := bound[key]
+		if !checked {
+			var count int64
+			err := db.Model(vendor).Where("user_id = ? AND server_id = ?", m.CreatorID, serverID).Count(&count).Error
+			if err != nil {
+				return nil, err
+			}
+			ok = count > 0
+			bound[key] = ok
+		}
+		if ok {
+			kept = append(kept, m)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/synctv-org/synctv/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrRoomCreatorChanged = errors.New("room creator has changed")
+
+// SaveRoomTransfer replaces the pending offer of the room
+func SaveRoomTransfer(t *model.RoomTransfer) error {
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(t).Error
+}
+
+func GetRoomTransfer(roomID string) (*model.RoomTransfer, error) {
+	t := &model.RoomTransfer{}
+	err := db.Where("room_id = ?", roomID).First(t).Error
+	return t, HandleNotFound(err, "transfer")
+}
+
+// GetRoomTransfersTo returns the offers to the user that have not expired
+func GetRoomTransfersTo(userID string) ([]*model.RoomTransfer, error) {
+	list := []*model.RoomTransfer{}
+	err := db.Where("to_id = ? AND expires_at > ?", userID, time.Now()).
+		Order("created_at desc").
+		Find(&list).Error
+	return list, err
+}
+
+func DeleteRoomTransfer(roomID string) error {
+	result := db.Where("room_id = ?", roomID).Delete(&model.RoomTransfer{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound("transfer")
+	}
+	return nil
+}
+
+// TransferRoom makes the member toID the creator of the room instead of fromID,
+// fromID gets the role and permissions toID had. If maxCount is not 0, it is the
+// number of rooms toID may create.
+func TransferRoom(roomID, fromID, toID string, maxCount int64) error {
+	return Transactional(func(tx *gorm.DB) error {
+		if maxCount != 0 {
+			var count int64
+			err := tx.Model(&model.Room{}).Where("creator_id = ?", toID).Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count >= maxCount {
+				return errors.New("room count is over limit")
+			}
+		}
+
+		to := &model.RoomMember{}
+		err := tx.Where("room_id = ? AND user_id = ?", roomID, toID).First(to).Error
+		if err != nil {
+			return HandleNotFound(err, "room member")
+		}
+		if to.Status.IsNotActive() {
+			return errors.New("the new owner is not an active member")
+		}
+
+		result := tx.Model(&model.Room{}).
+			Where("id = ? AND creator_id = ?", roomID, fromID).
+			Update("creator_id", toID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRoomCreatorChanged
+		}
+
+		err = tx.Model(&model.RoomMember{}).
+			Where("room_id = ? AND user_id = ?", roomID, fromID).
+			Updates(map[string]any{
+				"status":            model.RoomMemberStatusActive,
+				"role":              to.Role,
+				"permissions":       to.Permissions,
+				"admin_permissions": to.AdminPermissions,
+			}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&model.RoomMember{}).
+			Where("room_id = ? AND user_id = ?", roomID, toID).
+			Updates(map[string]any{
+				"role":              model.RoomMemberRoleCreator,
+				"permissions":       model.AllPermissions,
+				"admin_permissions": model.AllAdminPermissions,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("room_id = ?", roomID).Delete(&model.RoomTransfer{}).Error
+	})
+}
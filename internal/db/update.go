@@ -15,7 +15,7 @@ type dbVersion struct {
 	Upgrade     func(*gorm.DB) error
 }
 
-const CurrentVersion = "0.0.6"
+const CurrentVersion = "0.0.23"
 
 var models = []any{
 	new(model.Setting),
@@ -29,6 +29,19 @@ var models = []any{
 	new(model.AlistVendor),
 	new(model.EmbyVendor),
 	new(model.VendorBackend),
+	new(model.ChatMessage),
+	new(model.Danmaku),
+	new(model.RoomSchedule),
+	new(model.RoomPlayback),
+	new(model.RoomWebhook),
+	new(model.WebhookDelivery),
+	new(model.RoomInvite),
+	new(model.RoomTemplate),
+	new(model.RoomTemplateMovie),
+	new(model.RoomAuditLog),
+	new(model.RoomTransfer),
+	new(model.RoomRole),
+	new(model.RoomRoleMember),
 }
 
 var dbVersions = map[string]dbVersion{
@@ -60,6 +73,57 @@ var dbVersions = map[string]dbVersion{
 		NextVersion: "0.0.6",
 	},
 	"0.0.6": {
+		NextVersion: "0.0.7",
+	},
+	"0.0.7": {
+		NextVersion: "0.0.8",
+	},
+	"0.0.8": {
+		NextVersion: "0.0.9",
+	},
+	"0.0.9": {
+		NextVersion: "0.0.10",
+	},
+	"0.0.10": {
+		NextVersion: "0.0.11",
+	},
+	"0.0.11": {
+		NextVersion: "0.0.12",
+	},
+	"0.0.12": {
+		NextVersion: "0.0.13",
+	},
+	"0.0.13": {
+		NextVersion: "0.0.14",
+	},
+	"0.0.14": {
+		NextVersion: "0.0.15",
+	},
+	"0.0.15": {
+		NextVersion: "0.0.16",
+	},
+	"0.0.16": {
+		NextVersion: "0.0.17",
+	},
+	"0.0.17": {
+		NextVersion: "0.0.18",
+	},
+	"0.0.18": {
+		NextVersion: "0.0.19",
+	},
+	"0.0.19": {
+		NextVersion: "0.0.20",
+	},
+	"0.0.20": {
+		NextVersion: "0.0.21",
+	},
+	"0.0.21": {
+		NextVersion: "0.0.22",
+	},
+	"0.0.22": {
+		NextVersion: "0.0.23",
+	},
+	"0.0.23": {
 		NextVersion: "",
 	},
 }
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"time"
+
+	"github.com/synctv-org/synctv/internal/model"
+)
+
+func CreateRoomWebhook(w *model.RoomWebhook) error {
+	return db.Create(w).Error
+}
+
+func GetRoomWebhooks(roomID string) ([]*model.RoomWebhook, error) {
+	list := []*model.RoomWebhook{}
+	err := db.Where("room_id = ?", roomID).Order("created_at asc").Find(&list).Error
+	return list, err
+}
+
+func GetRoomWebhook(roomID, id string) (*model.RoomWebhook, error) {
+	w := &model.RoomWebhook{}
+	err := db.Where("room_id = ? AND id = ?", roomID, id).First(w).Error
+	return w, HandleNotFound(err, "webhook")
+}
+
+func GetWebhookByID(id string) (*model.RoomWebhook, error) {
+	w := &model.RoomWebhook{}
+	err := db.Where("id = ?", id).First(w).Error
+	return w, HandleNotFound(err, "webhook")
+}
+
+func DeleteRoomWebhook(roomID, id string) error {
+	result := db.Where("room_id = ? AND id = ?", roomID, id).Delete(&model.RoomWebhook{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound("webhook")
+	}
+	return nil
+}
+
+func CreateWebhookDeliveries(list []*model.WebhookDelivery) error {
+	return db.Create(list).Error
+}
+
+// the pending deliveries whose next attempt is due, oldest first
+func GetDueWebhookDeliveries(now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	list := []*model.WebhookDelivery{}
+	err := db.
+		Where("status = ? AND next_attempt_at <= ?", model.WebhookDeliveryPending, now).
+		Order("id asc").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// LeaseWebhookDelivery postpones the next attempt of the delivery to until,
+// ok is false if it has been leased by someone else since it was loaded.
+func LeaseWebhookDelivery(d *model.WebhookDelivery, until time.Time) (ok bool, err error) {
+	result := db.Model(&model.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", d.ID, model.WebhookDeliveryPending, d.NextAttemptAt).
+		Update("next_attempt_at", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	d.NextAttemptAt = until
+	return result.RowsAffected == 1, nil
+}
+
+func SaveWebhookDelivery(d *model.WebhookDelivery) error {
+	return db.Save(d).Error
+}
+
+// the deliveries of the webhook, newest first
+func GetWebhookDeliveries(webhookID string, page, pageSize int) ([]*model.WebhookDelivery, int64, error) {
+	var total int64
+	err := db.Model(&model.WebhookDelivery{}).Where("webhook_id = ?", webhookID).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	list := []*model.WebhookDelivery{}
+	err = db.Where("webhook_id = ?", webhookID).
+		Order("id desc").
+		Scopes(Paginate(page, pageSize)).
+		Find(&list).Error
+	return list, total, err
+}
+
+// DeleteOldWebhookDeliveries deletes the finished deliveries created before t
+func DeleteOldWebhookDeliveries(t time.Time) error {
+	return db.
+		Where("status <> ? AND created_at < ?", model.WebhookDeliveryPending, t).
+		Delete(&model.WebhookDelivery{}).Error
+}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditActionMemberBan              AuditAction = "member_ban"
+	AuditActionMemberUnban            AuditAction = "member_unban"
+	AuditActionMemberPermissions      AuditAction = "member_permissions"
+	AuditActionMemberRole             AuditAction = "member_role"
+	AuditActionMemberAdminPermissions AuditAction = "member_admin_permissions"
+	AuditActionSettings               AuditAction = "settings"
+	AuditActionPassword               AuditAction = "password"
+	AuditActionMovieDelete            AuditAction = "movie_delete"
+	AuditActionMovieClear             AuditAction = "movie_clear"
+	AuditActionOwnerTransfer          AuditAction = "owner_transfer"
+	AuditActionMemberRoles            AuditAction = "member_roles"
+	AuditActionRoleCreate             AuditAction = "role_create"
+	AuditActionRoleUpdate             AuditAction = "role_update"
+	AuditActionRoleDelete             AuditAction = "role_delete"
+)
+
+var AuditActions = []AuditAction{
+	AuditActionMemberBan,
+	AuditActionMemberUnban,
+	AuditActionMemberPermissions,
+	AuditActionMemberRole,
+	AuditActionMemberAdminPermissions,
+	AuditActionSettings,
+	AuditActionPassword,
+	AuditActionMovieDelete,
+	AuditActionMovieClear,
+	AuditActionOwnerTransfer,
+	AuditActionMemberRoles,
+	AuditActionRoleCreate,
+	AuditActionRoleUpdate,
+	AuditActionRoleDelete,
+}
+
+// RoomAuditLog records an administrative action in a room, the entries are never updated
+type RoomAuditLog struct {
+	ID        uint64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CreatedAt time.Time   `gorm:"index" json:"createdAt"`
+	RoomID    string      `gorm:"not null;index;type:char(32)" json:"-"`
+	ActorID   string      `gorm:"index;type:char(32)" json:"actorId"`
+	Action    AuditAction `gorm:"not null;index;type:varchar(32)" json:"action"`
+	// the member, movie or role the action was taken on, or the new owner, empty if it is the room
+	TargetID string `gorm:"index;type:char(32)" json:"targetId"`
+	// json of the values before and after the action
+	OldValue string `gorm:"type:text" json:"oldValue"`
+	NewValue string `gorm:"type:text" json:"newValue"`
+}
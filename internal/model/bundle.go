@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	json "github.com/json-iterator/go"
+)
+
+const RoomBundleVersion = 1
+
+// RoomBundle is a room exported to move it to another instance or to recreate it.
+// The users are referenced by name, the ids are remapped on import.
+// The password, the secrets of the vendors and the headers of the movies are not exported,
+// the urls of the movies are exported as they are and may carry the tokens of their servers.
+type RoomBundle struct {
+	Version    int                 `json:"version"`
+	ExportedAt time.Time           `json:"exportedAt"`
+	Room       RoomBundleRoom      `json:"room"`
+	Movies     []*RoomBundleMovie  `json:"movies"`
+	Members    []*RoomBundleMember `json:"members,omitempty"`
+}
+
+type RoomBundleRoom struct {
+	Name        string        `json:"name"`
+	CreatorName string        `json:"creatorName"`
+	Settings    *RoomSettings `json:"settings"`
+}
+
+// UnmarshalJSON decodes the settings over the default ones,
+// so a setting missing from the bundle keeps its default instead of the zero value.
+func (r *RoomBundleRoom) UnmarshalJSON(data []byte) error {
+	type plain RoomBundleRoom
+	p := plain{
+		Settings: DefaultRoomSettings(),
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*r = RoomBundleRoom(p)
+	return nil
+}
+
+// the movies are in playlist order
+type RoomBundleMovie struct {
+	CreatorName string    `json:"creatorName"`
+	Base        BaseMovie `json:"base"`
+}
+
+type RoomBundleMember struct {
+	Username         string               `json:"username"`
+	Status           RoomMemberStatus     `json:"status"`
+	Role             RoomMemberRole       `json:"role"`
+	Permissions      RoomMemberPermission `json:"permissions"`
+	AdminPermissions RoomAdminPermission  `json:"adminPermissions"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type ChatMessage struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `gorm:"index"`
+	RoomID    string    `gorm:"not null;index;type:char(32)"`
+	SenderID  string    `gorm:"not null;type:char(32)"`
+	// the receiver of a whisper, empty if the message is sent to the whole room
+	ReceiverID string `gorm:"not null;default:'';index;type:char(32)"`
+	Message    string `gorm:"not null;type:text"`
+}
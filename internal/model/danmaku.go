@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type DanmakuMode uint32
+
+const (
+	DanmakuModeScroll DanmakuMode = iota
+	DanmakuModeTop
+	DanmakuModeBottom
+)
+
+type Danmaku struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time
+	MovieID   string `gorm:"not null;index:idx_danmaku_movie_time,priority:1;type:char(32)"`
+	// seconds from the start of the movie
+	Time float64 `gorm:"not null;index:idx_danmaku_movie_time,priority:2"`
+	// empty if imported from the source of the movie
+	SenderID string      `gorm:"index;type:char(32)"`
+	Content  string      `gorm:"not null;type:varchar(512)"`
+	Color    uint32      `gorm:"not null;default:16777215"`
+	Mode     DanmakuMode `gorm:"not null;default:0"`
+}
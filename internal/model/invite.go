@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/synctv-org/synctv/utils"
+	"gorm.io/gorm"
+)
+
+// RoomInvite lets the users holding the token join the room as active members
+// with the preset permissions, without the password and review
+type RoomInvite struct {
+	ID          string               `gorm:"primaryKey;type:char(32)" json:"id"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	RoomID      string               `gorm:"not null;index;type:char(32)" json:"-"`
+	CreatorID   string               `gorm:"index;type:char(32)" json:"creatorId"`
+	Token       string               `gorm:"not null;uniqueIndex;type:varchar(32)" json:"token"`
+	Permissions RoomMemberPermission `json:"permissions"`
+	MaxUses     uint64               `gorm:"not null;default:1" json:"maxUses"`
+	Uses        uint64               `gorm:"not null;default:0" json:"uses"`
+	ExpiresAt   time.Time            `gorm:"not null;index" json:"expiresAt"`
+}
+
+func (i *RoomInvite) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = utils.SortUUID()
+	}
+	if i.Token == "" {
+		i.Token = utils.RandString(32)
+	}
+	return nil
+}
+
+func (i *RoomInvite) Usable() bool {
+	return i.Uses < i.MaxUses && time.Now().Before(i.ExpiresAt)
+}
@@ -115,6 +115,13 @@ const (
 	PermissionSetRoomSettings
 	PermissionSetRoomPassword
 	PermissionDeleteRoom
+	PermissionMuteMember
+	PermissionDeleteChatMessage
+	PermissionSetChatModeration
+	PermissionScheduleMovie
+	PermissionManageInvites
+	PermissionExportRoom
+	PermissionViewAuditLog
 
 	AllAdminPermissions     RoomAdminPermission = math.MaxUint32
 	NoAdminPermission       RoomAdminPermission = 0
@@ -122,7 +129,14 @@ const (
 		PermissionBanRoomMember |
 		PermissionSetUserPermission |
 		PermissionSetRoomSettings |
-		PermissionSetRoomPassword
+		PermissionSetRoomPassword |
+		PermissionMuteMember |
+		PermissionDeleteChatMessage |
+		PermissionSetChatModeration |
+		PermissionScheduleMovie |
+		PermissionManageInvites |
+		PermissionExportRoom |
+		PermissionViewAuditLog
 )
 
 func (p RoomAdminPermission) Has(permission RoomAdminPermission) bool {
@@ -146,6 +160,14 @@ type RoomMember struct {
 	Role             RoomMemberRole   `gorm:"not null;default:1"`
 	Permissions      RoomMemberPermission
 	AdminPermissions RoomAdminPermission
+	// unix milliseconds until which the member cannot chat, 0 means not muted
+	MutedUntil int64 `gorm:"not null;default:0"`
+	// the roles are removed together with the member
+	Roles []*RoomRoleMember `gorm:"foreignKey:UserID,RoomID;references:UserID,RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (r *RoomMember) IsMuted() bool {
+	return r.MutedUntil > time.Now().UnixMilli()
 }
 
 var ErrNoPermission = errors.New("no permission")
@@ -10,13 +10,15 @@ import (
 )
 
 type Movie struct {
-	ID        string    `gorm:"primaryKey;type:char(32)" json:"id"`
-	CreatedAt time.Time `json:"-"`
-	UpdatedAt time.Time `json:"-"`
-	Position  uint      `gorm:"not null" json:"-"`
-	RoomID    string    `gorm:"not null;index;type:char(32)" json:"-"`
-	CreatorID string    `gorm:"index;type:char(32)" json:"creatorId"`
-	Base      BaseMovie `gorm:"embedded;embeddedPrefix:base_" json:"base"`
+	ID        string          `gorm:"primaryKey;type:char(32)" json:"id"`
+	CreatedAt time.Time       `json:"-"`
+	UpdatedAt time.Time       `json:"-"`
+	Position  uint            `gorm:"not null" json:"-"`
+	RoomID    string          `gorm:"not null;index;type:char(32)" json:"-"`
+	CreatorID string          `gorm:"index;type:char(32)" json:"creatorId"`
+	Base      BaseMovie       `gorm:"embedded;embeddedPrefix:base_" json:"base"`
+	Danmaku   []*Danmaku      `gorm:"foreignKey:MovieID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Schedules []*RoomSchedule `gorm:"foreignKey:MovieID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
 
 func (m *Movie) BeforeCreate(tx *gorm.DB) error {
@@ -33,6 +35,7 @@ type BaseMovie struct {
 	Proxy      bool                 `json:"proxy"`
 	RtmpSource bool                 `json:"rtmpSource"`
 	Type       string               `json:"type"`
+	Duration   float64              `json:"duration"` // seconds, 0 if unknown
 	Headers    map[string]string    `gorm:"serializer:fastjson;type:text" json:"headers"`
 	Subtitles  map[string]*Subtitle `gorm:"serializer:fastjson;type:text" json:"subtitles"`
 	VendorInfo VendorInfo           `gorm:"embedded;embeddedPrefix:vendor_info_" json:"vendorInfo,omitempty"`
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// RoomPlayback is the persisted current movie and status of a room
+type RoomPlayback struct {
+	RoomID    string    `gorm:"primaryKey;type:char(32)"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	MovieID   string    `gorm:"type:char(32)"`
+	IsLive    bool      `gorm:"default:false"`
+	Seek      float64   `gorm:"default:0"`
+	Rate      float64   `gorm:"default:1"`
+	Playing   bool      `gorm:"default:false"`
+	// when the seek was taken, a playing movie has advanced since
+	LastUpdate time.Time
+}
@@ -39,8 +39,16 @@ type Room struct {
 	Settings           *RoomSettings `gorm:"foreignKey:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"settings"`
 	CreatorID          string        `gorm:"index;type:char(32)"`
 	HashedPassword     []byte
-	GroupUserRelations []*RoomMember `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Movies             []*Movie      `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	GroupUserRelations []*RoomMember   `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Movies             []*Movie        `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ChatMessages       []*ChatMessage  `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Schedules          []*RoomSchedule `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Playback           *RoomPlayback   `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Webhooks           []*RoomWebhook  `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Invites            []*RoomInvite   `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	AuditLogs          []*RoomAuditLog `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Transfer           *RoomTransfer   `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Roles              []*RoomRole     `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (r *Room) BeforeCreate(tx *gorm.DB) error {
@@ -86,8 +94,52 @@ type RoomSettings struct {
 	CanSetCurrentMovie  bool `gorm:"default:true" json:"can_set_current_movie"`
 	CanSetCurrentStatus bool `gorm:"default:true" json:"can_set_current_status"`
 	CanSendChatMessage  bool `gorm:"default:true" json:"can_send_chat_message"`
+
+	DisableChatHistory bool `gorm:"default:false" json:"disable_chat_history"`
+	// 0 means keep the chat history forever
+	ChatHistoryRetentionDays int64 `gorm:"default:30" json:"chat_history_retention_days"`
+	// number of latest chat messages pushed to a client after it connected
+	ChatHistoryReplayCount int64 `gorm:"default:0" json:"chat_history_replay_count"`
+	DisableWhisper         bool  `gorm:"default:false" json:"disable_whisper"`
+	// minimum seconds between two messages of a member, 0 means no limit
+	ChatSlowModeInterval int64 `gorm:"default:0" json:"chat_slow_mode_interval"`
+	// blocked words and /regexps/, one per line
+	ChatBlocklist string `gorm:"type:text" json:"chat_blocklist"`
+
+	// a client drifting more than this (seconds) is asked to nudge its playback rate
+	SyncNudgeThreshold float64 `gorm:"default:0.5" json:"sync_nudge_threshold"`
+	// a client drifting more than this (seconds) is asked to seek
+	SyncSeekThreshold float64 `gorm:"default:3" json:"sync_seek_threshold"`
+
+	// only the host can play, pause, seek and change the rate
+	HostOnlyControl bool `gorm:"default:false" json:"host_only_control"`
+
+	// pause the room while members are buffering and resume when they are ready
+	AutoPauseOnBuffering bool `gorm:"default:false" json:"auto_pause_on_buffering"`
+	// pause when more than this fraction of the online members are buffering, 0 means any member
+	AutoPauseBufferingRatio float64 `gorm:"default:0" json:"auto_pause_buffering_ratio"`
+
+	// fraction of the online members an option of a poll needs to win
+	PollQuorum float64 `gorm:"default:0.5" json:"poll_quorum"`
+	// seconds a poll is open for
+	PollTimeout int64 `gorm:"default:60" json:"poll_timeout"`
+
+	// what to play after the current movie ended
+	PlaybackMode PlaybackMode `gorm:"type:varchar(16);default:manual" json:"playback_mode"`
+	// remove a movie from the list once it has been played to the end
+	DeletePlayedMovies bool `gorm:"default:false" json:"delete_played_movies"`
 }
 
+type PlaybackMode string
+
+const (
+	PlaybackModeManual     PlaybackMode = "manual"
+	PlaybackModeSequential PlaybackMode = "sequential"
+	PlaybackModeLoop       PlaybackMode = "loop"
+	PlaybackModeShuffle    PlaybackMode = "shuffle"
+	PlaybackModeRepeatOne  PlaybackMode = "repeat_one"
+)
+
 func DefaultRoomSettings() *RoomSettings {
 	return &RoomSettings{
 		Hidden:                 false,
@@ -103,5 +155,26 @@ func DefaultRoomSettings() *RoomSettings {
 		CanSetCurrentMovie:  true,
 		CanSetCurrentStatus: true,
 		CanSendChatMessage:  true,
+
+		DisableChatHistory:       false,
+		ChatHistoryRetentionDays: 30,
+		ChatHistoryReplayCount:   0,
+		DisableWhisper:           false,
+		ChatSlowModeInterval:     0,
+		ChatBlocklist:            "",
+
+		SyncNudgeThreshold: 0.5,
+		SyncSeekThreshold:  3,
+
+		HostOnlyControl: false,
+
+		AutoPauseOnBuffering:    false,
+		AutoPauseBufferingRatio: 0,
+
+		PollQuorum:  0.5,
+		PollTimeout: 60,
+
+		PlaybackMode:       PlaybackModeManual,
+		DeletePlayedMovies: false,
 	}
 }
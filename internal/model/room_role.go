@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/synctv-org/synctv/utils"
+	"gorm.io/gorm"
+)
+
+// RoomRole is a named set of permissions defined by the room creator, the members
+// holding it get its permissions in addition to their own
+type RoomRole struct {
+	ID               string               `gorm:"primaryKey;type:char(32)" json:"id"`
+	CreatedAt        time.Time            `json:"createdAt"`
+	UpdatedAt        time.Time            `json:"updatedAt"`
+	RoomID           string               `gorm:"not null;uniqueIndex:idx_room_role_name;type:char(32)" json:"-"`
+	Name             string               `gorm:"not null;uniqueIndex:idx_room_role_name;type:varchar(32)" json:"name"`
+	Permissions      RoomMemberPermission `json:"permissions"`
+	AdminPermissions RoomAdminPermission  `json:"adminPermissions"`
+	Members          []*RoomRoleMember    `gorm:"foreignKey:RoleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (r *RoomRole) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = utils.SortUUID()
+	}
+	return nil
+}
+
+// RoomRoleMember assigns a role to a member of the room
+type RoomRoleMember struct {
+	CreatedAt time.Time
+	RoleID    string `gorm:"primaryKey;type:char(32)"`
+	UserID    string `gorm:"primaryKey;index;type:char(32)"`
+	RoomID    string `gorm:"not null;index;type:char(32)"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/synctv-org/synctv/utils"
+	"gorm.io/gorm"
+)
+
+// RoomSchedule sets a movie as the current one and starts playing it at StartAt
+type RoomSchedule struct {
+	ID        string    `gorm:"primaryKey;type:char(32)" json:"id"`
+	CreatedAt time.Time `json:"-"`
+	RoomID    string    `gorm:"not null;index;type:char(32)" json:"-"`
+	MovieID   string    `gorm:"not null;index;type:char(32)" json:"movieId"`
+	CreatorID string    `gorm:"index;type:char(32)" json:"creatorId"`
+	Title     string    `gorm:"type:varchar(256)" json:"title"`
+	StartAt   time.Time `gorm:"not null;index" json:"startAt"`
+}
+
+func (s *RoomSchedule) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = utils.SortUUID()
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/synctv-org/synctv/utils"
+	"gorm.io/gorm"
+)
+
+// RoomTemplate is saved by a user to create rooms with the same settings and starter playlist
+type RoomTemplate struct {
+	ID        string        `gorm:"primaryKey;type:char(32)" json:"id"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	CreatorID string        `gorm:"not null;index;type:char(32)" json:"creatorId"`
+	Name      string        `gorm:"not null;type:varchar(64)" json:"name"`
+	Settings  *RoomSettings `gorm:"serializer:fastjson;type:text" json:"settings"`
+	// the default permissions of the members of the rooms created from the template
+	Permissions RoomMemberPermission `json:"permissions"`
+	Movies      []*RoomTemplateMovie `gorm:"foreignKey:TemplateID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"movies"`
+}
+
+func (t *RoomTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = utils.SortUUID()
+	}
+	return nil
+}
+
+type RoomTemplateMovie struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"-"`
+	TemplateID string    `gorm:"not null;index;type:char(32)" json:"-"`
+	Position   uint      `gorm:"not null" json:"-"`
+	Base       BaseMovie `gorm:"embedded;embeddedPrefix:base_" json:"base"`
+}
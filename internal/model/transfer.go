@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// RoomTransfer is the offer of the creator of a room to hand the room over to another user,
+// a room has at most one pending offer
+type RoomTransfer struct {
+	RoomID    string    `gorm:"primaryKey;type:char(32)" json:"roomId"`
+	CreatedAt time.Time `json:"createdAt"`
+	FromID    string    `gorm:"not null;type:char(32)" json:"fromId"`
+	ToID      string    `gorm:"not null;index;type:char(32)" json:"toId"`
+	ExpiresAt time.Time `gorm:"not null" json:"expiresAt"`
+}
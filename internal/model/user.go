@@ -43,19 +43,21 @@ type User struct {
 	ID                   string `gorm:"primaryKey;type:char(32)" json:"id"`
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
-	RegisteredByProvider bool            `gorm:"not null;default:false"`
-	RegisteredByEmail    bool            `gorm:"not null;default:false"`
-	UserProviders        []*UserProvider `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Username             string          `gorm:"not null;uniqueIndex;type:varchar(32)"`
-	HashedPassword       []byte          `gorm:"not null"`
-	Email                sql.NullString  `gorm:"type:varchar(128);uniqueIndex"`
-	Role                 Role            `gorm:"not null;default:2"`
-	RoomMembers          []*RoomMember   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Rooms                []*Room         `gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Movies               []*Movie        `gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	BilibiliVendor       *BilibiliVendor `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	AlistVendor          []*AlistVendor  `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	EmbyVendor           []*EmbyVendor   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	RegisteredByProvider bool              `gorm:"not null;default:false"`
+	RegisteredByEmail    bool              `gorm:"not null;default:false"`
+	UserProviders        []*UserProvider   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Username             string            `gorm:"not null;uniqueIndex;type:varchar(32)"`
+	HashedPassword       []byte            `gorm:"not null"`
+	Email                sql.NullString    `gorm:"type:varchar(128);uniqueIndex"`
+	Role                 Role              `gorm:"not null;default:2"`
+	RoomMembers          []*RoomMember     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Rooms                []*Room           `gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Movies               []*Movie          `gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	BilibiliVendor       *BilibiliVendor   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	AlistVendor          []*AlistVendor    `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	EmbyVendor           []*EmbyVendor     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	RoomTemplates        []*RoomTemplate   `gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	RoomRoles            []*RoomRoleMember `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (u *User) CheckPassword(password string) bool {
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"github.com/synctv-org/synctv/utils"
+	"gorm.io/gorm"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventMovieAdded         WebhookEvent = "movie_added"
+	WebhookEventMovieDeleted       WebhookEvent = "movie_deleted"
+	WebhookEventCurrentChanged     WebhookEvent = "current_changed"
+	WebhookEventPlay               WebhookEvent = "play"
+	WebhookEventPause              WebhookEvent = "pause"
+	WebhookEventMemberJoined       WebhookEvent = "member_joined"
+	WebhookEventMemberApproved     WebhookEvent = "member_approved"
+	WebhookEventMemberBanned       WebhookEvent = "member_banned"
+	WebhookEventLivePublishStarted WebhookEvent = "live_publish_started"
+)
+
+var WebhookEvents = []WebhookEvent{
+	WebhookEventMovieAdded,
+	WebhookEventMovieDeleted,
+	WebhookEventCurrentChanged,
+	WebhookEventPlay,
+	WebhookEventPause,
+	WebhookEventMemberJoined,
+	WebhookEventMemberApproved,
+	WebhookEventMemberBanned,
+	WebhookEventLivePublishStarted,
+}
+
+type RoomWebhook struct {
+	ID        string    `gorm:"primaryKey;type:char(32)" json:"id"`
+	CreatedAt time.Time `json:"-"`
+	RoomID    string    `gorm:"not null;index;type:char(32)" json:"-"`
+	CreatorID string    `gorm:"index;type:char(32)" json:"creatorId"`
+	URL       string    `gorm:"not null;type:varchar(1024)" json:"url"`
+	// the key of the HMAC-SHA256 signature of the payloads
+	Secret string `gorm:"not null;type:varchar(64)" json:"-"`
+	// empty means all events
+	Events     []WebhookEvent     `gorm:"serializer:fastjson;type:text" json:"events"`
+	Deliveries []*WebhookDelivery `gorm:"foreignKey:WebhookID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (w *RoomWebhook) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = utils.SortUUID()
+	}
+	return nil
+}
+
+func (w *RoomWebhook) Subscribed(event WebhookEvent) bool {
+	return len(w.Events) == 0 || utils.In(w.Events, event)
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	WebhookID string                `gorm:"not null;index;type:char(32)"`
+	Event     WebhookEvent          `gorm:"not null;type:varchar(32)"`
+	Payload   string                `gorm:"not null;type:text"`
+	Status    WebhookDeliveryStatus `gorm:"not null;type:varchar(16);index:idx_webhook_delivery_due,priority:1"`
+	// the time of the next attempt while pending
+	NextAttemptAt time.Time `gorm:"index:idx_webhook_delivery_due,priority:2"`
+	Attempts      int       `gorm:"not null;default:0"`
+	// the response status code of the last attempt, 0 if there was no response
+	StatusCode int    `gorm:"not null;default:0"`
+	Error      string `gorm:"type:varchar(512)"`
+}
@@ -0,0 +1,127 @@
+package op
+
+import (
+	json "github.com/json-iterator/go"
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	"gorm.io/gorm"
+)
+
+type auditMember struct {
+	Status           model.RoomMemberStatus     `json:"status"`
+	Role             model.RoomMemberRole       `json:"role"`
+	Permissions      model.RoomMemberPermission `json:"permissions"`
+	AdminPermissions model.RoomAdminPermission  `json:"adminPermissions"`
+}
+
+type auditMovie struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatorID string `json:"creatorId"`
+}
+
+type auditOwner struct {
+	CreatorID string `json:"creatorId"`
+}
+
+type auditRole struct {
+	Name             string                     `json:"name"`
+	Permissions      model.RoomMemberPermission `json:"permissions"`
+	AdminPermissions model.RoomAdminPermission  `json:"adminPermissions"`
+}
+
+type auditPassword struct {
+	HasPassword bool `json:"hasPassword"`
+}
+
+// auditMemberOf returns the member as recorded in the audit log, nil if it is not a member
+func (r *Room) auditMemberOf(userID string) *auditMember {
+	m, err := r.LoadRoomMember(userID)
+	if err != nil {
+		return nil
+	}
+	return &auditMember{
+		Status:           m.Status,
+		Role:             m.Role,
+		Permissions:      m.Permissions,
+		AdminPermissions: m.AdminPermissions,
+	}
+}
+
+func newAuditRole(r *model.RoomRole) *auditRole {
+	return &auditRole{
+		Name:             r.Name,
+		Permissions:      r.Permissions,
+		AdminPermissions: r.AdminPermissions,
+	}
+}
+
+func newAuditMovie(m *model.Movie) *auditMovie {
+	return &auditMovie{
+		ID:        m.ID,
+		Name:      m.Base.Name,
+		CreatorID: m.CreatorID,
+	}
+}
+
+// auditSettings returns the fields of the settings named by the keys of the update
+func auditSettings(s *model.RoomSettings, update map[string]any) map[string]any {
+	all := map[string]any{}
+	b, err := json.Marshal(s)
+	if err == nil {
+		_ = json.Unmarshal(b, &all)
+	}
+	m := make(map[string]any, len(update))
+	for k := range update {
+		m[k] = all[k]
+	}
+	return m
+}
+
+func auditValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// audit appends an entry to the audit log of the room, the action has already been taken so errors are only logged
+func (r *Room) audit(actor *User, action model.AuditAction, targetID string, oldValue, newValue any) {
+	auditRoom(r.ID, actor, action, targetID, oldValue, newValue)
+}
+
+func auditRoom(roomID string, actor *User, action model.AuditAction, targetID string, oldValue, newValue any) {
+	err := db.CreateRoomAuditLog(&model.RoomAuditLog{
+		RoomID:   roomID,
+		ActorID:  actor.ID,
+		Action:   action,
+		TargetID: targetID,
+		OldValue: auditValue(oldValue),
+		NewValue: auditValue(newValue),
+	})
+	if err != nil {
+		log.Errorf("room %s: save audit log of %s error: %v", roomID, action, err)
+	}
+}
+
+// auditMemberChange runs the change of the member and records it in the audit log
+func (r *Room) auditMemberChange(actor *User, action model.AuditAction, userID string, change func() error) error {
+	old := r.auditMemberOf(userID)
+	if err := change(); err != nil {
+		return err
+	}
+	r.audit(actor, action, userID, old, r.auditMemberOf(userID))
+	return nil
+}
+
+func (u *User) RoomAuditLogs(room *Room, page, pageSize int, scopes ...func(*gorm.DB) *gorm.DB) ([]*model.RoomAuditLog, int64, error) {
+	if !u.HasRoomAdminPermission(room, model.PermissionViewAuditLog) {
+		return nil, 0, model.ErrNoPermission
+	}
+	return db.GetRoomAuditLogs(room.ID, page, pageSize, scopes...)
+}
@@ -0,0 +1,155 @@
+package op
+
+import (
+	"context"
+	"time"
+
+	json "github.com/json-iterator/go"
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/broadcaster"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	pb "github.com/synctv-org/synctv/proto/message"
+	"google.golang.org/protobuf/proto"
+)
+
+var roomBroadcaster broadcaster.Broadcaster = broadcaster.NewLocal()
+
+func SetBroadcaster(b broadcaster.Broadcaster) {
+	roomBroadcaster = b
+}
+
+// controlChannel carries the events about the rooms a node may have cached
+// without being subscribed to them, every node subscribes to it on start.
+const controlChannel = "_control"
+
+func subscribeControl() {
+	if _, err := roomBroadcaster.Subscribe(controlChannel, handleControlEvent); err != nil {
+		log.Errorf("subscribe control channel error: %v", err)
+	}
+}
+
+func handleControlEvent(e *broadcaster.Event) {
+	switch e.Type {
+	case broadcaster.EventEvictRoom:
+		closeCachedRoom(e.RoomID)
+	case broadcaster.EventInvalidateRoomRoles:
+		if r, ok := roomCache.Load(e.RoomID); ok {
+			r.Value().roles.Store(nil)
+		}
+	case broadcaster.EventSyncRoomCurrent:
+		if r, ok := roomCache.Load(e.RoomID); ok {
+			r.Value().followCurrent(e.Data)
+		}
+	case broadcaster.EventInvalidateRoomMovies:
+		if r, ok := roomCache.Load(e.RoomID); ok {
+			r.Value().movies.reload()
+		}
+	case broadcaster.EventInvalidateRoomSettings:
+		if r, ok := roomCache.Load(e.RoomID); ok {
+			r.Value().reloadSettings()
+		}
+	case broadcaster.EventInvalidateRoomMember:
+		if r, ok := roomCache.Load(e.RoomID); ok {
+			r.Value().members.Delete(e.UserID)
+		}
+	}
+}
+
+func publishControlEvent(e *broadcaster.Event) {
+	if err := publishRoomEvent(controlChannel, e, nil); err != nil {
+		log.Errorf("room %s: publish control event error: %v", e.RoomID, err)
+	}
+}
+
+// evictRoom closes the room on every node,
+// so they load it again from the database instead of changing the cached one.
+func evictRoom(roomID string) {
+	closeCachedRoom(roomID)
+	publishControlEvent(&broadcaster.Event{
+		Type:   broadcaster.EventEvictRoom,
+		RoomID: roomID,
+	})
+}
+
+// invalidatePeers tells the other nodes to reload what changed in the room on this node
+func (r *Room) invalidatePeers(t broadcaster.EventType) {
+	publishControlEvent(&broadcaster.Event{
+		Type:   t,
+		RoomID: r.ID,
+	})
+}
+
+// invalidateMember drops the cached member on every node,
+// it must be called whenever the member is changed in the database.
+func (r *Room) invalidateMember(userID string) {
+	r.members.Delete(userID)
+	publishControlEvent(&broadcaster.Event{
+		Type:   broadcaster.EventInvalidateRoomMember,
+		RoomID: r.ID,
+		UserID: userID,
+	})
+}
+
+// publishCurrent sends the current movie and status changed on this node to the other nodes
+func (r *Room) publishCurrent() {
+	data, err := json.Marshal(playbackOf(r.ID, r.current.Current()))
+	if err != nil {
+		log.Errorf("room %s: marshal current error: %v", r.ID, err)
+		return
+	}
+	publishControlEvent(&broadcaster.Event{
+		Type:   broadcaster.EventSyncRoomCurrent,
+		RoomID: r.ID,
+		Data:   data,
+	})
+}
+
+// followCurrent applies the current movie and status changed on another node,
+// that node persists it and advances the room when the movie ends.
+func (r *Room) followCurrent(data []byte) {
+	p := &model.RoomPlayback{}
+	if err := json.Unmarshal(data, p); err != nil {
+		log.Errorf("room %s: unmarshal current error: %v", r.ID, err)
+		return
+	}
+	r.current.follow(p)
+	r.stopEndTimer()
+}
+
+func (r *Room) reloadSettings() {
+	rs, err := db.GetOrCreateRoomSettings(r.ID)
+	if err != nil {
+		log.Errorf("room %s: reload settings error: %v", r.ID, err)
+		return
+	}
+	r.Settings = rs
+}
+
+func closeCachedRoom(roomID string) {
+	if e, ok := roomCache.Load(roomID); ok {
+		if err := e.Value().savePlayback(); err != nil {
+			log.Errorf("room %s: save playback error: %v", roomID, err)
+		}
+	}
+	_ = CloseRoomById(roomID)
+}
+
+// only element messages are shared with other nodes,
+// control messages such as ping are generated by every node itself
+func publishRoomEvent(roomID string, e *broadcaster.Event, data Message) error {
+	if data != nil {
+		em, ok := data.(*pb.ElementMessage)
+		if !ok {
+			return nil
+		}
+		b, err := proto.Marshal(em)
+		if err != nil {
+			return err
+		}
+		e.Data = b
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	return roomBroadcaster.Publish(ctx, roomID, e)
+}
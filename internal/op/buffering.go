@@ -0,0 +1,148 @@
+package op
+
+import (
+	"time"
+
+	pb "github.com/synctv-org/synctv/proto/message"
+)
+
+// followRemoteBuffering tracks the members buffering on another node from the presence it broadcast,
+// a presence carries the view of the node that sent it, PRESENCE_LEAVE means the member left every node.
+func (r *Room) followRemoteBuffering(node string, t pb.ElementMessageType, p *pb.Presence) {
+	userID := p.GetUser().GetUserid()
+	if userID == "" {
+		return
+	}
+	var changed bool
+	r.bufferingLock.Lock()
+	nodes := r.remoteBuffering[userID]
+	_, was := nodes[node]
+	switch {
+	case t == pb.ElementMessageType_PRESENCE_LEAVE:
+		changed = len(nodes) != 0
+		delete(r.remoteBuffering, userID)
+	case p.Buffering && p.Devices > 0:
+		if nodes == nil {
+			if r.remoteBuffering == nil {
+				r.remoteBuffering = make(map[string]map[string]time.Time)
+			}
+			nodes = make(map[string]time.Time)
+			r.remoteBuffering[userID] = nodes
+		}
+		nodes[node] = time.Now()
+		changed = !was
+	case was:
+		delete(nodes, node)
+		if len(nodes) == 0 {
+			delete(r.remoteBuffering, userID)
+		}
+		changed = true
+	}
+	r.bufferingLock.Unlock()
+	if changed {
+		_ = r.checkBuffering(nil)
+	}
+}
+
+// remoteBufferingUsers returns the members buffering on the other nodes that are still alive
+// and forgets the ones of the gone nodes, a node that just started may not have reported
+// its people yet, so a recent report counts too.
+func (r *Room) remoteBufferingUsers() []string {
+	r.bufferingLock.Lock()
+	defer r.bufferingLock.Unlock()
+	users := make([]string, 0, len(r.remoteBuffering))
+	for userID, nodes := range r.remoteBuffering {
+		for node, at := range nodes {
+			if time.Since(at) > remotePeopleNumTTL && !r.hub.nodeAlive(node) {
+				delete(nodes, node)
+			}
+		}
+		if len(nodes) == 0 {
+			delete(r.remoteBuffering, userID)
+			continue
+		}
+		users = append(users, userID)
+	}
+	return users
+}
+
+// bufferingReached counts the members online on every node, a member is buffering
+// when any of their devices is.
+func (r *Room) bufferingReached() bool {
+	online := make(map[string]bool)
+	r.RangeClients(func(c *Client) bool {
+		s, _ := c.CheckStatus()
+		online[c.u.ID] = online[c.u.ID] || s.Buffering
+		return true
+	})
+	if r.hub != nil {
+		for _, userID := range r.hub.remoteUserIDs() {
+			if _, ok := online[userID]; !ok {
+				online[userID] = false
+			}
+		}
+		for _, userID := range r.remoteBufferingUsers() {
+			online[userID] = true
+		}
+	}
+	if len(online) == 0 {
+		return false
+	}
+	var buffering int
+	for _, b := range online {
+		if b {
+			buffering++
+		}
+	}
+	if r.Settings.AutoPauseBufferingRatio <= 0 {
+		return buffering > 0
+	}
+	return float64(buffering)/float64(len(online)) > r.Settings.AutoPauseBufferingRatio
+}
+
+// checkBuffering pauses the room when too many members are buffering,
+// and resumes it once they are ready, trigger is the client whose buffering changed.
+func (r *Room) checkBuffering(trigger *Client) error {
+	if !r.Settings.AutoPauseOnBuffering {
+		return nil
+	}
+	var (
+		t      pb.ElementMessageType
+		status *Status
+		ok     bool
+	)
+	if r.bufferingReached() {
+		t = pb.ElementMessageType_PAUSE
+		status, ok = r.current.AutoPause()
+	} else {
+		t = pb.ElementMessageType_PLAY
+		status, ok = r.current.AutoResume()
+	}
+	if !ok {
+		return nil
+	}
+	r.currentChanged()
+	msg := &pb.MovieStatusChanged{
+		Status: &pb.MovieStatus{
+			Playing: status.Playing,
+			Seek:    status.Seek,
+			Rate:    status.Rate,
+		},
+	}
+	if trigger != nil {
+		msg.Sender = &pb.Sender{
+			Username: trigger.u.Username,
+			Userid:   trigger.u.ID,
+		}
+	}
+	return r.Broadcast(&pb.ElementMessage{
+		Type:               t,
+		MovieStatusChanged: msg,
+	})
+}
+
+func (c *Client) SetBuffering(buffering bool) error {
+	status, _ := c.CheckStatus()
+	status.Buffering = buffering
+	return c.SetCheckStatus(status)
+}
@@ -0,0 +1,60 @@
+package op
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+)
+
+func (u *User) ExportRoom(room *Room, withMembers bool) (*model.RoomBundle, error) {
+	if !u.HasRoomAdminPermission(room, model.PermissionExportRoom) {
+		return nil, model.ErrNoPermission
+	}
+	return db.ExportRoom(room.ID, withMembers)
+}
+
+// ImportRoom creates a room owned by the user from the bundle,
+// it is subject to the same limits as CreateRoom.
+// Every movie is given to the user and the members are dropped,
+// the vendor movies the user can not play are skipped.
+func (u *User) ImportRoom(b *model.RoomBundle, name, password string) (*RoomEntry, error) {
+	if err := ValidateBundle(b); err != nil {
+		return nil, err
+	}
+	conf, err := db.WithBundle(b, &u.User, false)
+	if err != nil {
+		return nil, err
+	}
+	return u.CreateRoom(name, password, conf...)
+}
+
+// ValidateBundle checks the settings of the bundle like SetSettings
+// and the movies like the ones added through AddMovie
+func ValidateBundle(b *model.RoomBundle) error {
+	if b.Room.Settings != nil {
+		if err := validateSettings(b.Room.Settings); err != nil {
+			return fmt.Errorf("settings: %w", err)
+		}
+	}
+	for _, m := range b.Movies {
+		if err := validateBaseMovie(&m.Base); err != nil {
+			return fmt.Errorf("movie %s: %w", m.Base.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateBaseMovie checks a movie that is not added through AddMovie
+func validateBaseMovie(m *model.BaseMovie) error {
+	switch {
+	case m.VendorInfo.Vendor == model.VendorBilibili && m.VendorInfo.Bilibili == nil:
+		return errors.New("bilibili payload is nil")
+	case m.VendorInfo.Vendor == model.VendorAlist && m.VendorInfo.Alist == nil:
+		return errors.New("alist payload is nil")
+	case m.VendorInfo.Vendor == model.VendorEmby && m.VendorInfo.Emby == nil:
+		return errors.New("emby payload is nil")
+	}
+	return (&Movie{Movie: &model.Movie{Base: *m}}).Validate()
+}
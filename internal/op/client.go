@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
 	"github.com/synctv-org/synctv/internal/model"
 	pb "github.com/synctv-org/synctv/proto/message"
 )
@@ -19,18 +21,71 @@ type Client struct {
 	conn    *websocket.Conn
 	timeOut time.Duration
 	closed  uint32
+
+	// clock offset (client - server) and round trip time in milliseconds,
+	// reported by the client through time sync
+	timeOffset atomic.Int64
+	rtt        atomic.Int64
+	timeSynced atomic.Bool
+
+	checkStatus atomic.Pointer[ClientStatus]
+
+	// send protojson text frames instead of protobuf binary frames
+	json bool
+
+	policy     SlowConsumerPolicy
+	maxDropped uint64
+	// held while the queued messages are rearranged by the slow consumer policy
+	sendLock sync.Mutex
+	dropped  atomic.Uint64
+	evicted  atomic.Bool
 }
 
-func newClient(user *User, room *Room, conn *websocket.Conn) *Client {
-	return &Client{
-		r:       room,
-		u:       user,
-		c:       make(chan Message, 128),
-		conn:    conn,
-		timeOut: 10 * time.Second,
+type ClientConf func(*Client)
+
+func WithJSONEncoding() ClientConf {
+	return func(c *Client) {
+		c.json = true
 	}
 }
 
+// ClientStatus is the playback status the client reported by its last check
+type ClientStatus struct {
+	Playing   bool
+	Seek      float64
+	Buffering bool
+	// client - server in seconds
+	Drift     float64
+	CheckedAt time.Time
+}
+
+func newClient(user *User, room *Room, conn *websocket.Conn, conf ...ClientConf) *Client {
+	c := &Client{
+		r:          room,
+		u:          user,
+		c:          make(chan Message, 128),
+		conn:       conn,
+		timeOut:    10 * time.Second,
+		policy:     slowConsumerPolicy,
+		maxDropped: slowConsumerMaxDropped,
+	}
+	for _, cc := range conf {
+		cc(c)
+	}
+	return c
+}
+
+// ReadOnly reports whether the client has no connection to send messages from,
+// e.g. an event stream. It only receives the messages of the room
+// and does not count as online.
+func (c *Client) ReadOnly() bool {
+	return c.conn == nil
+}
+
+func (c *Client) JSONEncoding() bool {
+	return c.json
+}
+
 func (c *Client) User() *User {
 	return c.u
 }
@@ -39,18 +94,101 @@ func (c *Client) Room() *Room {
 	return c.r
 }
 
+func (c *Client) SetTimeOffset(offset, rtt time.Duration) {
+	c.timeOffset.Store(offset.Milliseconds())
+	c.rtt.Store(rtt.Milliseconds())
+	c.timeSynced.Store(true)
+}
+
+// TimeOffset returns the clock offset of the client relative to the server,
+// synced is false if the client has never reported its offset.
+func (c *Client) TimeOffset() (offset time.Duration, synced bool) {
+	return time.Duration(c.timeOffset.Load()) * time.Millisecond, c.timeSynced.Load()
+}
+
+func (c *Client) RTT() time.Duration {
+	return time.Duration(c.rtt.Load()) * time.Millisecond
+}
+
+// SetDrift stores the drift (client - server) in seconds of a check,
+// the rest of the status of the last check is kept.
+func (c *Client) SetDrift(drift float64) {
+	status, _ := c.CheckStatus()
+	status.Drift = drift
+	if err := c.SetCheckStatus(status); err != nil {
+		log.Errorf("client: %s, room: %s, set drift error: %v", c.u.ID, c.r.ID, err)
+	}
+}
+
+// Drift returns the drift of the client reported by the last check,
+// checkedAt is zero if the client has never checked.
+func (c *Client) Drift() (drift float64, checkedAt time.Time) {
+	s, ok := c.CheckStatus()
+	if !ok {
+		return 0, time.Time{}
+	}
+	return s.Drift, s.CheckedAt
+}
+
+// CheckStatus returns the status of the last check,
+// ok is false if the client has never checked.
+func (c *Client) CheckStatus() (status ClientStatus, ok bool) {
+	s := c.checkStatus.Load()
+	if s == nil {
+		return ClientStatus{}, false
+	}
+	return *s, true
+}
+
+// SetCheckStatus stores the status and tells the room about it
+// when the client starts or stops playing, buffering or being in sync,
+// the room may be paused or resumed when the buffering changed.
+func (c *Client) SetCheckStatus(status ClientStatus) error {
+	status.CheckedAt = time.Now()
+	pre := c.checkStatus.Swap(&status)
+	if pre != nil &&
+		pre.Playing == status.Playing &&
+		pre.Buffering == status.Buffering &&
+		c.r.inSync(pre.Drift) == c.r.inSync(status.Drift) {
+		return nil
+	}
+	if err := c.r.broadcastPresence(pb.ElementMessageType_PRESENCE_UPDATE, c.u.ID); err != nil {
+		return err
+	}
+	if pre == nil && status.Buffering || pre != nil && pre.Buffering != status.Buffering {
+		return c.r.checkBuffering(c)
+	}
+	return nil
+}
+
 func (c *Client) Broadcast(msg Message, conf ...BroadcastConf) error {
-	return c.r.hub.Broadcast(msg, conf...)
+	return c.r.Broadcast(msg, conf...)
 }
 
 func (c *Client) SendChatMessage(message string) error {
 	if !c.u.HasRoomPermission(c.r, model.PermissionSendChatMessage) {
 		return model.ErrNoPermission
 	}
+	if err := c.r.checkChatMessage(c.u, message); err != nil {
+		return err
+	}
+	msg := &model.ChatMessage{
+		CreatedAt: time.Now(),
+		RoomID:    c.r.ID,
+		SenderID:  c.u.ID,
+		Message:   message,
+	}
+	// only the messages in the history have an id and can be retracted
+	if !c.r.Settings.DisableChatHistory {
+		if err := db.CreateChatMessage(msg); err != nil {
+			log.Errorf("save chat message error: %v", err)
+		}
+	}
 	return c.Broadcast(&pb.ElementMessage{
 		Type: pb.ElementMessageType_CHAT_MESSAGE,
-		Time: time.Now().UnixMilli(),
+		Time: msg.CreatedAt.UnixMilli(),
 		ChatResp: &pb.ChatResp{
+			Id:      msg.ID,
 			Message: message,
 			Sender: &pb.Sender{
 				Userid:   c.u.ID,
@@ -60,16 +198,58 @@ func (c *Client) SendChatMessage(message string) error {
 	})
 }
 
-func (c *Client) Send(msg Message) error {
-	c.wg.Add(1)
-	defer c.wg.Done()
-	if c.Closed() {
-		return ErrAlreadyClosed
+const maxChatHistoryReplayCount = 100
+
+// SendChatHistory pushes the latest chat messages of the room to the client
+func (c *Client) SendChatHistory() error {
+	count := c.r.Settings.ChatHistoryReplayCount
+	if count <= 0 || c.r.Settings.DisableChatHistory {
+		return nil
+	}
+	if count > maxChatHistoryReplayCount {
+		count = maxChatHistoryReplayCount
+	}
+	msgs, err := db.GetChatMessagesBefore(c.r.ID, 0, int(count), db.WhereChatVisibleTo(c.u.ID))
+	if err != nil {
+		return err
+	}
+	for i := len(msgs) - 1; i >= 0; i-- {
+		m := msgs[i]
+		if m.ReceiverID != "" {
+			err = c.Send(newWhisperMessage(m.CreatedAt, m.SenderID, GetUserName(m.SenderID), m.ReceiverID, m.Message))
+		} else {
+			err = c.Send(&pb.ElementMessage{
+				Type: pb.ElementMessageType_CHAT_MESSAGE,
+				Time: m.CreatedAt.UnixMilli(),
+				ChatResp: &pb.ChatResp{
+					Id:      m.ID,
+					Message: m.Message,
+					Sender: &pb.Sender{
+						Userid:   m.SenderID,
+						Username: GetUserName(m.SenderID),
+					},
+				},
+			})
+		}
+		if err != nil {
+			return err
+		}
 	}
-	c.c <- msg
 	return nil
 }
 
+// Send never blocks, a full channel is handled like for a broadcast
+func (c *Client) Send(msg Message) error {
+	return c.deliver(msg)
+}
+
+func (c *Client) encode(msg Message) Message {
+	if em, ok := msg.(*pb.ElementMessage); ok && c.json {
+		return &pb.JSONElementMessage{ElementMessage: em}
+	}
+	return msg
+}
+
 func (c *Client) Close() error {
 	if !atomic.CompareAndSwapUint32(&c.closed, 0, 1) {
 		return ErrAlreadyClosed
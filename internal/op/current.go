@@ -3,11 +3,17 @@ package op
 import (
 	"sync"
 	"time"
+
+	"github.com/synctv-org/synctv/internal/model"
 )
 
 type current struct {
 	current Current
 	lock    sync.RWMutex
+	// paused by AutoPause and not changed by anyone since
+	autoPaused bool
+	// changed since it was last persisted
+	dirty bool
 }
 
 type Current struct {
@@ -31,6 +37,33 @@ type Status struct {
 	lastUpdate time.Time `json:"-"`
 }
 
+// restoreCurrent continues a persisted playback state,
+// a playing movie advances from the time its seek was taken.
+func restoreCurrent(p *model.RoomPlayback) *current {
+	if p.Rate <= 0 {
+		p.Rate = 1.0
+	}
+	lastUpdate := p.LastUpdate
+	if lastUpdate.IsZero() {
+		lastUpdate = p.UpdatedAt
+	}
+	if lastUpdate.IsZero() || lastUpdate.After(time.Now()) {
+		lastUpdate = time.Now()
+	}
+	return &current{
+		current: Current{
+			MovieID: p.MovieID,
+			IsLive:  p.IsLive,
+			Status: Status{
+				Seek:       p.Seek,
+				Rate:       p.Rate,
+				Playing:    p.Playing,
+				lastUpdate: lastUpdate,
+			},
+		},
+	}
+}
+
 func newStatus() Status {
 	return Status{
 		Seek:       0,
@@ -54,6 +87,8 @@ func (c *current) SetMovie(movieID string, isLive, play bool) {
 	c.current.IsLive = isLive
 	c.current.SetSeek(0, 0)
 	c.current.Status.Playing = play
+	c.autoPaused = false
+	c.dirty = true
 }
 
 func (c *current) Status() Status {
@@ -68,17 +103,96 @@ func (c *current) SetStatus(playing bool, seek, rate, timeDiff float64) *Status
 	defer c.lock.Unlock()
 
 	s := c.current.SetStatus(playing, seek, rate, timeDiff)
+	c.autoPaused = false
+	c.dirty = true
 	return &s
 }
 
+// AutoPause pauses the playing movie, ok is false if there is nothing to pause
+func (c *current) AutoPause() (status *Status, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.current.MovieID == "" || c.current.IsLive {
+		return nil, false
+	}
+	c.current.UpdateStatus()
+	if !c.current.Status.Playing {
+		return nil, false
+	}
+	s := c.current.SetStatus(false, c.current.Status.Seek, c.current.Status.Rate, 0)
+	c.autoPaused = true
+	c.dirty = true
+	return &s, true
+}
+
+// AutoResume plays the movie again if it is still paused by AutoPause
+func (c *current) AutoResume() (status *Status, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.autoPaused {
+		return nil, false
+	}
+	c.autoPaused = false
+	c.dirty = true
+	s := c.current.SetStatus(true, c.current.Status.Seek, c.current.Status.Rate, 0)
+	return &s, true
+}
+
 func (c *current) SetSeekRate(seek, rate, timeDiff float64) *Status {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	s := c.current.SetSeekRate(seek, rate, timeDiff)
+	c.dirty = true
 	return &s
 }
 
+// takeDirty returns the current state and clears the dirty flag,
+// ok is false if it is not changed on this node since the last call.
+// The seek is the one taken at Status.lastUpdate, a playing movie is not advanced.
+func (c *current) takeDirty() (cur Current, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.dirty {
+		return Current{}, false
+	}
+	c.dirty = false
+	return c.current, true
+}
+
+// follow applies the state changed on another node,
+// it is persisted by that node so it does not make this one dirty.
+func (c *current) follow(p *model.RoomPlayback) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if p.Rate <= 0 {
+		p.Rate = 1.0
+	}
+	if p.LastUpdate.IsZero() {
+		p.LastUpdate = time.Now()
+	}
+	c.current = Current{
+		MovieID: p.MovieID,
+		IsLive:  p.IsLive,
+		Status: Status{
+			Seek:       p.Seek,
+			Rate:       p.Rate,
+			Playing:    p.Playing,
+			lastUpdate: p.LastUpdate,
+		},
+	}
+	c.autoPaused = false
+	c.dirty = false
+}
+
+func (c *current) setDirty() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.dirty = true
+}
+
 func (c *Current) UpdateStatus() Status {
 	if c.IsLive {
 		c.Status.lastUpdate = time.Now()
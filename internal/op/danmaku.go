@@ -0,0 +1,169 @@
+package op
+
+import (
+	"compress/flate"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	pb "github.com/synctv-org/synctv/proto/message"
+)
+
+var ErrCurrentMovieChanged = errors.New("current movie changed")
+
+// SendDanmaku sends a danmaku at the position t of the current movie,
+// movieID is the movie the client is playing and can be empty.
+func (c *Client) SendDanmaku(movieID string, t float64, content string, color uint32, mode model.DanmakuMode) error {
+	if !c.u.HasRoomPermission(c.r, model.PermissionSendChatMessage) {
+		return model.ErrNoPermission
+	}
+	current := c.r.Current()
+	if current.MovieID == "" {
+		return ErrNoCurrentMovie
+	}
+	if movieID != "" && movieID != current.MovieID {
+		return ErrCurrentMovieChanged
+	}
+	if err := c.r.checkChatMessage(c.u, content); err != nil {
+		return err
+	}
+	if t < 0 {
+		t = 0
+	}
+	switch mode {
+	case model.DanmakuModeScroll, model.DanmakuModeTop, model.DanmakuModeBottom:
+	default:
+		mode = model.DanmakuModeScroll
+	}
+	// the position of a live stream means nothing to those watching later
+	if !current.IsLive {
+		err := db.CreateDanmaku(&model.Danmaku{
+			MovieID:  current.MovieID,
+			Time:     t,
+			SenderID: c.u.ID,
+			Content:  content,
+			Color:    color,
+			Mode:     mode,
+		})
+		if err != nil {
+			log.Errorf("save danmaku error: %v", err)
+		}
+	}
+	return c.Broadcast(&pb.ElementMessage{
+		Type: pb.ElementMessageType_DANMAKU,
+		Time: time.Now().UnixMilli(),
+		Danmaku: &pb.Danmaku{
+			Sender: &pb.Sender{
+				Userid:   c.u.ID,
+				Username: c.u.Username,
+			},
+			MovieId: current.MovieID,
+			Time:    t,
+			Content: content,
+			Color:   color,
+			Mode:    uint32(mode),
+		},
+	})
+}
+
+func (u *User) ImportRoomMovieDanmaku(ctx context.Context, room *Room, movieID string) (int, error) {
+	if !u.HasRoomPermission(room, model.PermissionEditMovie) {
+		return 0, model.ErrNoPermission
+	}
+	m, err := room.GetMovieByID(movieID)
+	if err != nil {
+		return 0, err
+	}
+	return m.ImportDanmaku(ctx)
+}
+
+// ImportDanmaku replaces the imported danmaku of the movie with the ones of its source,
+// only bilibili movies are supported.
+func (m *Movie) ImportDanmaku(ctx context.Context) (int, error) {
+	info := m.Movie.Base.VendorInfo
+	if info.Vendor != model.VendorBilibili || info.Bilibili == nil || info.Bilibili.Cid == 0 {
+		return 0, errors.New("only bilibili videos have danmaku to import")
+	}
+	list, err := fetchBilibiliDanmaku(ctx, info.Bilibili.Cid)
+	if err != nil {
+		return 0, err
+	}
+	for _, d := range list {
+		d.MovieID = m.Movie.ID
+	}
+	return len(list), db.ReplaceImportedDanmaku(m.Movie.ID, list)
+}
+
+type bilibiliDanmakuList struct {
+	D []struct {
+		// time,mode,size,color,...
+		P    string `xml:"p,attr"`
+		Text string `xml:",chardata"`
+	} `xml:"d"`
+}
+
+func fetchBilibiliDanmaku(ctx context.Context, cid uint64) ([]*model.Danmaku, error) {
+	resp, err := resty.New().R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		SetQueryParam("oid", strconv.FormatUint(cid, 10)).
+		Get("https://api.bilibili.com/x/v1/dm/list.so")
+	if err != nil {
+		return nil, err
+	}
+	body := resp.RawBody()
+	defer body.Close()
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("fetch bilibili danmaku error: status %d", resp.StatusCode())
+	}
+	var r io.Reader = body
+	if resp.Header().Get("Content-Encoding") == "deflate" {
+		fr := flate.NewReader(body)
+		defer fr.Close()
+		r = fr
+	}
+	var l bilibiliDanmakuList
+	if err := xml.NewDecoder(r).Decode(&l); err != nil {
+		return nil, fmt.Errorf("decode bilibili danmaku error: %w", err)
+	}
+	list := make([]*model.Danmaku, 0, len(l.D))
+	for _, d := range l.D {
+		p := strings.Split(d.P, ",")
+		if len(p) < 4 {
+			continue
+		}
+		t, err := strconv.ParseFloat(p[0], 64)
+		if err != nil {
+			continue
+		}
+		var mode model.DanmakuMode
+		switch p[1] {
+		case "1", "2", "3", "6":
+			mode = model.DanmakuModeScroll
+		case "4":
+			mode = model.DanmakuModeBottom
+		case "5":
+			mode = model.DanmakuModeTop
+		default:
+			// advanced and scripted danmaku
+			continue
+		}
+		color, _ := strconv.ParseUint(p[3], 10, 32)
+		list = append(list, &model.Danmaku{
+			Time:    t,
+			Content: d.Text,
+			Color:   uint32(color),
+			Mode:    mode,
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,174 @@
+package op
+
+import (
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/synctv-org/synctv/internal/model"
+	pb "github.com/synctv-org/synctv/proto/message"
+)
+
+var ErrNotRoomHost = errors.New("only the host can control the playback")
+
+// HostID returns the user who drives the playback when host only control is enabled,
+// the creator is the host until the host is handed over.
+func (r *Room) HostID() string {
+	r.hostLock.RLock()
+	defer r.hostLock.RUnlock()
+	if r.hostID == "" {
+		return r.CreatorID
+	}
+	return r.hostID
+}
+
+func (r *Room) IsHost(userID string) bool {
+	return r.HostID() == userID
+}
+
+func (r *Room) hostState() (string, int64) {
+	r.hostLock.RLock()
+	defer r.hostLock.RUnlock()
+	return r.hostID, r.hostAt
+}
+
+func (r *Room) setHost(userID string) int64 {
+	r.hostLock.Lock()
+	defer r.hostLock.Unlock()
+	r.hostID = userID
+	// keep the order of the changes even if the clock of this node is behind
+	r.hostAt = max(time.Now().UnixMilli(), r.hostAt+1)
+	return r.hostAt
+}
+
+// adoptHost takes the host set on another node if it was set after the one known here,
+// so every node ends up with the same host.
+func (r *Room) adoptHost(userID string, at int64) {
+	r.hostLock.Lock()
+	defer r.hostLock.Unlock()
+	if at < r.hostAt || at == r.hostAt && userID <= r.hostID {
+		return
+	}
+	r.hostID = userID
+	r.hostAt = at
+}
+
+func (r *Room) SetHost(userID string) error {
+	if r.IsGuest(userID) {
+		return errors.New("guest cannot be host")
+	}
+	status, err := r.LoadMemberStatus(userID)
+	if err != nil {
+		return err
+	}
+	if status.IsNotActive() {
+		return errors.New("user is not an active member")
+	}
+	r.lazyInitHub()
+	if !r.hub.IsOnlineAnywhere(userID) {
+		return errors.New("user is not online")
+	}
+	return r.broadcastHostChanged(userID, r.setHost(userID))
+}
+
+// broadcastHostChanged tells the clients and the other nodes about the new host,
+// an empty userID means the creator.
+func (r *Room) broadcastHostChanged(userID string, at int64) error {
+	host := userID
+	if host == "" {
+		host = r.CreatorID
+	}
+	return r.Broadcast(&pb.ElementMessage{
+		Type: pb.ElementMessageType_HOST_CHANGED,
+		Time: at,
+		HostChanged: &pb.Sender{
+			Userid:   host,
+			Username: GetUserName(host),
+		},
+	})
+}
+
+// hostVacant reports whether the host is offline on every node,
+// wait is how long to wait for the other nodes to report who is online before deciding.
+func (r *Room) hostVacant() (vacant bool, wait time.Duration) {
+	r.lazyInitHub()
+	if wait := r.hub.peersSettleIn(); wait > 0 {
+		return false, wait
+	}
+	return !r.hub.IsOnlineAnywhere(r.HostID()), 0
+}
+
+// claimVacantHost makes the user who joined the host if the host is offline,
+// so the playback is never left to an offline user.
+func (r *Room) claimVacantHost(u *User) {
+	if u.IsGuest() {
+		return
+	}
+	vacant, wait := r.hostVacant()
+	if wait > 0 {
+		time.AfterFunc(wait, func() {
+			if r.hub.IsOnline(u.ID) {
+				r.claimVacantHost(u)
+			}
+		})
+		return
+	}
+	if !vacant {
+		return
+	}
+	if err := r.broadcastHostChanged(u.ID, r.setHost(u.ID)); err != nil {
+		log.Errorf("room: %s, broadcast host changed error: %v", r.ID, err)
+	}
+}
+
+// reassignHost hands the host over to a member online on any node, room admins first,
+// if nobody is online the host goes back to the creator until somebody joins.
+func (r *Room) reassignHost() error {
+	var next, nextAdmin string
+	pick := func(userID string) bool {
+		if r.IsGuest(userID) {
+			return true
+		}
+		if r.IsAdmin(userID) {
+			nextAdmin = userID
+			return false
+		}
+		if next == "" {
+			next = userID
+		}
+		return true
+	}
+	r.RangeClients(func(c *Client) bool {
+		return pick(c.u.ID)
+	})
+	if nextAdmin == "" {
+		for _, id := range r.hub.remoteUserIDs() {
+			if !pick(id) {
+				break
+			}
+		}
+	}
+	if nextAdmin != "" {
+		next = nextAdmin
+	}
+	return r.broadcastHostChanged(next, r.setHost(next))
+}
+
+func (r *Room) checkHostControl(userID string) error {
+	if !r.Settings.HostOnlyControl || r.IsHost(userID) {
+		return nil
+	}
+	// nobody can take over from an offline host otherwise
+	if vacant, _ := r.hostVacant(); vacant {
+		return nil
+	}
+	return ErrNotRoomHost
+}
+
+func (u *User) SetRoomHost(room *Room, userID string) error {
+	if !room.IsHost(u.ID) && !u.HasRoomAdminPermission(room, model.PermissionSetRoomSettings) {
+		return model.ErrNoPermission
+	}
+	return room.SetHost(userID)
+}
@@ -9,9 +9,11 @@ import (
 
 	"github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/broadcaster"
 	pb "github.com/synctv-org/synctv/proto/message"
 	"github.com/synctv-org/synctv/utils"
 	"github.com/zijiren233/gencontainer/rwmap"
+	"google.golang.org/protobuf/proto"
 )
 
 type clients struct {
@@ -19,21 +21,80 @@ type clients struct {
 	m    map[*Client]struct{}
 }
 
+// online returns the number of the clients that are not read-only,
+// a user having only read-only clients is not online.
+func (c *clients) online() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	n := 0
+	for cli := range c.m {
+		if !cli.ReadOnly() {
+			n++
+		}
+	}
+	return n
+}
+
 type Hub struct {
 	id        string
 	clients   rwmap.RWMap[string, *clients]
 	broadcast chan *broadcastMessage
-	exit      chan struct{}
-	closed    uint32
-	wg        sync.WaitGroup
+	// events waiting to be published to the other nodes,
+	// so a slow broadcaster never holds up the local delivery
+	publishQueue chan *pendingPublish
+	exit         chan struct{}
+	closed       uint32
+	wg           sync.WaitGroup
+
+	once        utils.Once
+	started     uint32
+	unsubscribe func()
+	// called with the element messages broadcast by other nodes
+	onRemoteMessage func(node string, msg *pb.ElementMessage)
+	// people number of the room on other nodes, keyed by node id
+	remotePeople rwmap.RWMap[string, *remotePeopleNum]
+	startedAt    time.Time
+	// the host known by this node, reported to the other nodes
+	hostState func() (hostID string, hostAt int64)
+	// called with the host reported by other nodes
+	onRemoteHost func(hostID string, hostAt int64)
+	// called with the votes and cancels of the poll sent by other nodes
+	onRemotePoll func(e *broadcaster.Event)
+	// called with the users who sent a chat message on other nodes
+	onRemoteChat func(userID string)
+
+	// held while a message is stamped and its receivers are collected,
+	// so a resuming client gets no message twice or out of order
+	replayLock sync.Mutex
+	replay     *replayBuffer
+}
+
+type remotePeopleNum struct {
+	num       int64
+	users     []string
+	updatedAt time.Time
+}
 
-	once utils.Once
+type pendingPublish struct {
+	event *broadcaster.Event
+	data  Message
 }
 
+const (
+	// every node reports its people number this often
+	peopleReportInterval = time.Second * 5
+	// a node that has not reported its people number for this long is considered gone
+	remotePeopleNumTTL = peopleReportInterval * 3
+)
+
 type broadcastMessage struct {
 	data         Message
 	ignoreClient []*Client
 	ignoreId     []string
+	// only deliver to the clients of this node
+	localOnly bool
+	// set for a direct message to the clients of this user
+	toUser string
 }
 
 type BroadcastConf func(*broadcastMessage)
@@ -50,44 +111,128 @@ func WithIgnoreId(id ...string) BroadcastConf {
 	}
 }
 
+func withLocalOnly() BroadcastConf {
+	return func(bm *broadcastMessage) {
+		bm.localOnly = true
+	}
+}
+
 func newHub(id string) *Hub {
-	return &Hub{
-		id:        id,
-		broadcast: make(chan *broadcastMessage, 128),
-		exit:      make(chan struct{}),
+	h := &Hub{
+		id:           id,
+		broadcast:    make(chan *broadcastMessage, 128),
+		publishQueue: make(chan *pendingPublish, 256),
+		exit:         make(chan struct{}),
+		replay:       newReplayBuffer(roomBroadcaster.NodeID()),
 	}
+	go h.publisher()
+	return h
 }
 
 func (h *Hub) Start() error {
 	h.once.Do(func() {
+		unsubscribe, err := roomBroadcaster.Subscribe(h.id, h.handleRemoteEvent)
+		if err != nil {
+			log.Errorf("hub: %s, subscribe broadcaster error: %v", h.id, err)
+		} else {
+			h.unsubscribe = unsubscribe
+		}
+		h.startedAt = time.Now()
 		go h.serve()
 		go h.ping()
+		atomic.StoreUint32(&h.started, 1)
 	})
 	return nil
 }
 
+func (h *Hub) handleRemoteEvent(e *broadcaster.Event) {
+	if h.Closed() {
+		return
+	}
+	switch e.Type {
+	case broadcaster.EventBroadcast:
+		msg := &pb.ElementMessage{}
+		if err := proto.Unmarshal(e.Data, msg); err != nil {
+			log.Errorf("hub: %s, unmarshal remote message error: %v", h.id, err)
+			return
+		}
+		if h.onRemoteMessage != nil {
+			h.onRemoteMessage(e.Node, msg)
+		}
+		_ = h.Broadcast(msg, WithIgnoreId(e.IgnoreIDs...), withLocalOnly())
+	case broadcaster.EventSendToUser:
+		msg := &pb.ElementMessage{}
+		if err := proto.Unmarshal(e.Data, msg); err != nil {
+			log.Errorf("hub: %s, unmarshal remote message error: %v", h.id, err)
+			return
+		}
+		_ = h.sendToLocalUser(e.UserID, msg)
+	case broadcaster.EventKickUser:
+		_ = h.kickLocalUser(e.UserID)
+	case broadcaster.EventPollVote, broadcaster.EventPollCancel:
+		if h.onRemotePoll != nil {
+			h.onRemotePoll(e)
+		}
+	case broadcaster.EventChatSent:
+		if h.onRemoteChat != nil {
+			h.onRemoteChat(e.UserID)
+		}
+	case broadcaster.EventPeopleNum:
+		h.remotePeople.Store(e.Node, &remotePeopleNum{
+			num:       e.PeopleNum,
+			users:     e.UserIDs,
+			updatedAt: time.Now(),
+		})
+		if e.HostAt != 0 && h.onRemoteHost != nil {
+			h.onRemoteHost(e.HostID, e.HostAt)
+		}
+	}
+}
+
+func (h *Hub) publish(e *broadcaster.Event, data Message) error {
+	return publishRoomEvent(h.id, e, data)
+}
+
+// publishAsync queues the event for the publisher without blocking,
+// the other nodes miss the event if the queue is full.
+func (h *Hub) publishAsync(e *broadcaster.Event, data Message) {
+	select {
+	case h.publishQueue <- &pendingPublish{event: e, data: data}:
+	default:
+		log.Errorf("hub: %s, publish queue is full, event %d dropped", h.id, e.Type)
+	}
+}
+
+func (h *Hub) publisher() {
+	for {
+		select {
+		case p := <-h.publishQueue:
+			if err := h.publish(p.event, p.data); err != nil {
+				log.Errorf("hub: %s, publish event %d error: %v", h.id, p.event.Type, err)
+			}
+		case <-h.exit:
+			return
+		}
+	}
+}
+
 func (h *Hub) serve() error {
 	for {
 		select {
 		case message := <-h.broadcast:
 			h.devMessage(message.data)
-			h.clients.Range(func(id string, clients *clients) bool {
-				clients.lock.RLock()
-				defer clients.lock.RUnlock()
-				for c := range clients.m {
-					if utils.In(message.ignoreId, c.u.ID) {
-						continue
-					}
-					if utils.In(message.ignoreClient, c) {
-						continue
-					}
-					if err := c.Send(message.data); err != nil {
-						c.Close()
-					}
+			for _, c := range h.stamp(message) {
+				if err := c.deliver(message.data); err != nil {
+					c.Close()
 				}
-
-				return true
-			})
+			}
+			// the local clients come first, the other nodes are reached in the background
+			if !message.localOnly {
+				h.publishAsync(&broadcaster.Event{
+					Type:      broadcaster.EventBroadcast,
+					IgnoreIDs: message.ignoreId,
+				}, message.data)
+			}
 		case <-h.exit:
 			log.Debugf("hub: %s, closed", h.id)
 			return nil
@@ -95,8 +240,52 @@ func (h *Hub) serve() error {
 	}
 }
 
+// stamp buffers the message for replay and returns the clients it goes to,
+// the clients are collected under the same lock so a client resuming meanwhile
+// gets the message either replayed or delivered, never both.
+func (h *Hub) stamp(message *broadcastMessage) []*Client {
+	h.replayLock.Lock()
+	defer h.replayLock.Unlock()
+	if msg, ok := message.data.(*pb.ElementMessage); ok {
+		if message.toUser != "" {
+			// the same message may be sent to several users, each gets its own sequence
+			msg = proto.Clone(msg).(*pb.ElementMessage)
+			message.data = msg
+		}
+		senders := make([]string, 0, len(message.ignoreClient))
+		for _, c := range message.ignoreClient {
+			senders = append(senders, c.u.ID)
+		}
+		h.replay.push(replayMessage{
+			msg:      msg,
+			ignoreId: message.ignoreId,
+			senders:  senders,
+			toUser:   message.toUser,
+		})
+	}
+	var targets []*Client
+	h.clients.Range(func(id string, clients *clients) bool {
+		if message.toUser != "" && id != message.toUser {
+			return true
+		}
+		clients.lock.RLock()
+		defer clients.lock.RUnlock()
+		for c := range clients.m {
+			if utils.In(message.ignoreId, c.u.ID) {
+				continue
+			}
+			if utils.In(message.ignoreClient, c) {
+				continue
+			}
+			targets = append(targets, c)
+		}
+		return true
+	})
+	return targets
+}
+
 func (h *Hub) ping() {
-	ticker := time.NewTicker(time.Second * 5)
+	ticker := time.NewTicker(peopleReportInterval)
 	defer ticker.Stop()
 	var (
 		pre     int64 = 0
@@ -105,12 +294,23 @@ func (h *Hub) ping() {
 	for {
 		select {
 		case <-ticker.C:
+			ids := h.localUserIDs()
+			e := &broadcaster.Event{
+				Type:      broadcaster.EventPeopleNum,
+				PeopleNum: int64(len(ids)),
+				UserIDs:   ids,
+			}
+			if h.hostState != nil {
+				e.HostID, e.HostAt = h.hostState()
+			}
+			h.publishAsync(e, nil)
 			current = h.PeopleNum()
 			if current != pre {
+				// every node counts the people by itself, so there is no need to publish
 				if err := h.Broadcast(&pb.ElementMessage{
 					Type:          pb.ElementMessageType_PEOPLE_CHANGED,
 					PeopleChanged: current,
-				}); err != nil {
+				}, withLocalOnly()); err != nil {
 					continue
 				}
 				pre = current
@@ -145,6 +345,9 @@ func (h *Hub) Close() error {
 		return ErrAlreadyClosed
 	}
 	close(h.exit)
+	if h.unsubscribe != nil {
+		h.unsubscribe()
+	}
 	h.clients.Range(func(id string, clients *clients) bool {
 		h.clients.Delete(id)
 		for c := range clients.m {
@@ -164,11 +367,20 @@ func (h *Hub) Broadcast(data Message, conf ...BroadcastConf) error {
 	if h.Closed() {
 		return ErrAlreadyClosed
 	}
-	h.once.Done()
 	msg := &broadcastMessage{data: data}
 	for _, c := range conf {
 		c(msg)
 	}
+	if atomic.LoadUint32(&h.started) == 0 {
+		// no client on this node yet, only the other nodes need the message
+		if !msg.localOnly {
+			h.publishAsync(&broadcaster.Event{
+				Type:      broadcaster.EventBroadcast,
+				IgnoreIDs: msg.ignoreId,
+			}, data)
+		}
+		return nil
+	}
 	select {
 	case h.broadcast <- msg:
 		return nil
@@ -197,6 +409,29 @@ func (h *Hub) RegClient(cli *Client) error {
 	return nil
 }
 
+// ResumeClient registers the client and sends it the messages broadcast after seq,
+// resumed is false if they are no longer buffered and the client has to refetch the room.
+func (h *Hub) ResumeClient(cli *Client, seq uint64) (resumed bool, err error) {
+	h.replayLock.Lock()
+	defer h.replayLock.Unlock()
+	if err := h.RegClient(cli); err != nil {
+		return false, err
+	}
+	msgs, ok := h.replay.since(seq)
+	for _, m := range msgs {
+		if m.toUser != "" && m.toUser != cli.u.ID {
+			continue
+		}
+		if utils.In(m.ignoreId, cli.u.ID) || utils.In(m.senders, cli.u.ID) {
+			continue
+		}
+		if err := cli.Send(m.msg); err != nil {
+			return false, err
+		}
+	}
+	return ok, nil
+}
+
 func (h *Hub) UnRegClient(cli *Client) error {
 	if h.Closed() {
 		return ErrAlreadyClosed
@@ -220,50 +455,144 @@ func (h *Hub) UnRegClient(cli *Client) error {
 	return nil
 }
 
+// PeopleNum returns the number of the users online on any node
 func (h *Hub) PeopleNum() int64 {
-	return h.clients.Len()
+	num := int64(len(h.localUserIDs()))
+	h.remotePeople.Range(func(node string, p *remotePeopleNum) bool {
+		if time.Since(p.updatedAt) > remotePeopleNumTTL {
+			h.remotePeople.CompareAndDelete(node, p)
+		} else {
+			num += p.num
+		}
+		return true
+	})
+	return num
 }
 
 func (h *Hub) SendToUser(userID string, data Message) (err error) {
 	if h.Closed() {
 		return ErrAlreadyClosed
 	}
-	cli, ok := h.clients.Load(userID)
-	if !ok {
+	h.publishAsync(&broadcaster.Event{
+		Type:   broadcaster.EventSendToUser,
+		UserID: userID,
+	}, data)
+	return h.sendToLocalUser(userID, data)
+}
+
+// sendToLocalUser queues the message like a broadcast,
+// so it is ordered with the broadcasts and buffered for replay.
+func (h *Hub) sendToLocalUser(userID string, data Message) (err error) {
+	h.wg.Add(1)
+	defer h.wg.Done()
+	if h.Closed() {
+		return ErrAlreadyClosed
+	}
+	if atomic.LoadUint32(&h.started) == 0 {
+		// no client on this node yet
 		return nil
 	}
-	cli.lock.RLock()
-	defer cli.lock.RUnlock()
-	for c := range cli.m {
-		if err = c.Send(data); err != nil {
-			c.Close()
-		}
+	select {
+	case h.broadcast <- &broadcastMessage{
+		data:      data,
+		toUser:    userID,
+		localOnly: true,
+	}:
+		return nil
+	case <-h.exit:
+		return ErrAlreadyClosed
 	}
-	return
 }
 
+// IsOnline reports whether the user has a client on this node that is not read-only
 func (h *Hub) IsOnline(userID string) bool {
-	_, ok := h.clients.Load(userID)
-	return ok
+	c, ok := h.clients.Load(userID)
+	return ok && c.online() > 0
+}
+
+// localUserIDs returns the users online on this node
+func (h *Hub) localUserIDs() []string {
+	ids := make([]string, 0)
+	h.clients.Range(func(id string, c *clients) bool {
+		if c.online() > 0 {
+			ids = append(ids, id)
+		}
+		return true
+	})
+	return ids
 }
 
+// remoteUserIDs returns the users online on the other nodes, a user may be on several of them
+func (h *Hub) remoteUserIDs() []string {
+	ids := make([]string, 0)
+	h.remotePeople.Range(func(node string, p *remotePeopleNum) bool {
+		if time.Since(p.updatedAt) <= remotePeopleNumTTL {
+			ids = append(ids, p.users...)
+		}
+		return true
+	})
+	return ids
+}
+
+// IsOnlineAnywhere reports whether the user has a client on any node
+// nodeAlive reports whether the node reported its people of the room lately
+func (h *Hub) nodeAlive(node string) bool {
+	p, ok := h.remotePeople.Load(node)
+	return ok && time.Since(p.updatedAt) <= remotePeopleNumTTL
+}
+
+func (h *Hub) IsOnlineAnywhere(userID string) bool {
+	return h.IsOnline(userID) || utils.In(h.remoteUserIDs(), userID)
+}
+
+// peersSettleIn returns how long to wait until every other node has reported
+// the users online on it since this hub started, 0 if they have.
+func (h *Hub) peersSettleIn() time.Duration {
+	if atomic.LoadUint32(&h.started) == 0 {
+		return peopleReportInterval * 2
+	}
+	return max(0, peopleReportInterval*2-time.Since(h.startedAt))
+}
+
+// OnlineCount returns the number of the clients of the user on this node that are not read-only
 func (h *Hub) OnlineCount(userID string) int {
 	c, ok := h.clients.Load(userID)
 	if !ok {
 		return 0
 	}
 	c.lock.RLock()
-	defer c.lock.RUnlock()
 	if len(c.m) == 0 {
 		h.clients.CompareAndDelete(userID, c)
 	}
-	return len(c.m)
+	c.lock.RUnlock()
+	return c.online()
+}
+
+func (h *Hub) RangeClients(f func(c *Client) bool) {
+	h.clients.Range(func(id string, clients *clients) bool {
+		clients.lock.RLock()
+		defer clients.lock.RUnlock()
+		for c := range clients.m {
+			if !f(c) {
+				return false
+			}
+		}
+		return true
+	})
 }
 
 func (h *Hub) KickUser(userID string) error {
 	if h.Closed() {
 		return ErrAlreadyClosed
 	}
+	h.publishAsync(&broadcaster.Event{
+		Type:   broadcaster.EventKickUser,
+		UserID: userID,
+	}, nil)
+	return h.kickLocalUser(userID)
+}
+
+func (h *Hub) kickLocalUser(userID string) error {
 	cli, ok := h.clients.Load(userID)
 	if !ok {
 		return nil
@@ -0,0 +1,136 @@
+package op
+
+import (
+	"errors"
+	"time"
+
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+)
+
+var (
+	ErrInviteExpired      = errors.New("invite has expired")
+	ErrInviteUsedUp       = errors.New("invite has been used up")
+	ErrInviteGuest        = errors.New("guest cannot redeem invites")
+	ErrInviteJoinClosed   = errors.New("room does not accept new members")
+	ErrInviteMemberBanned = errors.New("you are banned from this room")
+)
+
+func (u *User) RoomInvites(room *Room) ([]*model.RoomInvite, error) {
+	if !u.HasRoomAdminPermission(room, model.PermissionManageInvites) {
+		return nil, model.ErrNoPermission
+	}
+	return db.GetRoomInvites(room.ID)
+}
+
+func (u *User) CreateRoomInvite(room *Room, permissions model.RoomMemberPermission, maxUses uint64, expiresAt time.Time) (*model.RoomInvite, error) {
+	if !u.HasRoomAdminPermission(room, model.PermissionManageInvites) {
+		return nil, model.ErrNoPermission
+	}
+	// presetting other permissions is the same as setting them afterwards
+	if permissions != room.Settings.UserDefaultPermissions &&
+		!u.HasRoomAdminPermission(room, model.PermissionSetUserPermission) {
+		return nil, model.ErrNoPermission
+	}
+	if !expiresAt.After(time.Now()) {
+		return nil, ErrInviteExpired
+	}
+	if maxUses == 0 {
+		maxUses = 1
+	}
+	i := &model.RoomInvite{
+		RoomID:      room.ID,
+		CreatorID:   u.ID,
+		Permissions: permissions,
+		MaxUses:     maxUses,
+		ExpiresAt:   expiresAt,
+	}
+	return i, db.CreateRoomInvite(i)
+}
+
+func (u *User) RevokeRoomInvite(room *Room, id string) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionManageInvites) {
+		return model.ErrNoPermission
+	}
+	return db.DeleteRoomInvite(room.ID, id)
+}
+
+// RedeemRoomInvite makes the user an active member of the room of the invite,
+// a member who has already joined keeps the permissions and the invite is not used.
+func (u *User) RedeemRoomInvite(token string) (*RoomEntry, error) {
+	if u.IsGuest() {
+		return nil, ErrInviteGuest
+	}
+	invite, err := db.GetRoomInviteByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(invite.ExpiresAt) {
+		return nil, ErrInviteExpired
+	}
+	if invite.Uses >= invite.MaxUses {
+		return nil, ErrInviteUsedUp
+	}
+	roomE, err := LoadOrInitRoomByID(invite.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if err := roomE.Value().redeemInvite(u.ID, invite); err != nil {
+		return nil, err
+	}
+	return roomE, nil
+}
+
+func (r *Room) redeemInvite(userID string, invite *model.RoomInvite) error {
+	if r.IsCreator(userID) {
+		return nil
+	}
+	member, err := db.GetRoomMember(r.ID, userID)
+	var notFound db.ErrNotFound
+	switch {
+	case err == nil:
+		if member.Status.IsBanned() {
+			return ErrInviteMemberBanned
+		}
+		if member.Status.IsActive() {
+			return nil
+		}
+	case errors.As(err, &notFound):
+		if r.Settings.DisableJoinNewUser {
+			return ErrInviteJoinClosed
+		}
+	default:
+		return err
+	}
+	ok, err := db.UseRoomInvite(invite.ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// expired or used up by someone else meanwhile
+		return ErrInviteUsedUp
+	}
+	defer r.invalidateMember(userID)
+	member, created, err := db.FirstOrCreateRoomMemberRelation(
+		r.ID,
+		userID,
+		db.WithRoomMemberStatus(model.RoomMemberStatusActive),
+		db.WithRoomMemberPermissions(invite.Permissions),
+		db.WithRoomMemberRole(model.RoomMemberRoleMember),
+		db.WithRoomMemberAdminPermissions(model.NoAdminPermission),
+	)
+	if err != nil {
+		return err
+	}
+	if created {
+		r.emitWebhook(model.WebhookEventMemberJoined, WebhookData{
+			Member: webhookUserByID(userID),
+		})
+		return nil
+	}
+	if member.Status.IsPending() {
+		// joined before and was waiting for review
+		return db.RoomActivateInvitedMember(r.ID, userID, invite.Permissions)
+	}
+	return nil
+}
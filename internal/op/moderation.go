@@ -0,0 +1,193 @@
+package op
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/broadcaster"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/settings"
+	pb "github.com/synctv-org/synctv/proto/message"
+	"github.com/synctv-org/synctv/utils"
+)
+
+// ErrChatModerated is wrapped by the errors of the messages rejected by the moderation
+var ErrChatModerated = errors.New("message rejected")
+
+var (
+	ErrMuted          = fmt.Errorf("%w: you are muted", ErrChatModerated)
+	ErrBlockedMessage = fmt.Errorf("%w: message contains blocked words", ErrChatModerated)
+)
+
+type parsedBlocklist struct {
+	raw       string
+	blocklist *utils.Blocklist
+}
+
+var globalChatBlocklist atomic.Pointer[parsedBlocklist]
+
+// loadBlocklist parses raw again only if it changed since the last call
+func loadBlocklist(p *atomic.Pointer[parsedBlocklist], raw string) *utils.Blocklist {
+	if l := p.Load(); l != nil && l.raw == raw {
+		return l.blocklist
+	}
+	b, err := utils.ParseBlocklist(raw)
+	if err != nil {
+		// the blocklists are checked before saved,
+		// but a row written by an older version or by hand may still be broken
+		log.Errorf("parse chat blocklist error: %v", err)
+		b = nil
+	}
+	p.Store(&parsedBlocklist{raw: raw, blocklist: b})
+	return b
+}
+
+func (r *Room) isBlockedMessage(message string) bool {
+	return loadBlocklist(&globalChatBlocklist, settings.ChatBlocklist.Get()).Match(message) ||
+		loadBlocklist(&r.chatBlocklist, r.Settings.ChatBlocklist).Match(message)
+}
+
+// checkChatMessage applies the blocklists, the mute and the slow mode
+// to the message the user is going to send, admins are not limited by the slow mode.
+func (r *Room) checkChatMessage(u *User, message string) error {
+	if r.isBlockedMessage(message) {
+		return ErrBlockedMessage
+	}
+	// site admins may be no member of the room
+	if u.IsAdmin() {
+		return nil
+	}
+	member, err := r.LoadRoomMember(u.ID)
+	if err != nil {
+		return err
+	}
+	if member.IsMuted() {
+		return fmt.Errorf("%w until %s", ErrMuted, time.UnixMilli(member.MutedUntil).Format(time.RFC3339))
+	}
+	interval := time.Duration(r.Settings.ChatSlowModeInterval) * time.Second
+	if interval <= 0 || member.Role.IsAdmin() {
+		return nil
+	}
+	now := time.Now()
+	if last, ok := r.lastChatAt.Load(u.ID); ok {
+		if wait := interval - now.Sub(last); wait > 0 {
+			return fmt.Errorf("%w: slow mode, wait %s", ErrChatModerated, wait.Round(time.Second))
+		}
+	}
+	r.lastChatAt.Store(u.ID, now)
+	// the user may be connected to other nodes too
+	if r.hub != nil {
+		r.hub.publishAsync(&broadcaster.Event{
+			Type:   broadcaster.EventChatSent,
+			UserID: u.ID,
+		}, nil)
+	}
+	return nil
+}
+
+// handleRemoteChat starts the slow mode of the user who sent a chat message on another node
+func (r *Room) handleRemoteChat(userID string) {
+	r.lastChatAt.Store(userID, time.Now())
+}
+
+// sweepLastChatAt forgets the messages sent before the slow mode interval,
+// the ones within it are kept so that reconnecting does not skip the wait.
+func (r *Room) sweepLastChatAt() {
+	interval := time.Duration(r.Settings.ChatSlowModeInterval) * time.Second
+	r.lastChatAt.Range(func(userID string, last time.Time) bool {
+		if time.Since(last) >= interval {
+			r.lastChatAt.CompareAndDelete(userID, last)
+		}
+		return true
+	})
+}
+
+func (r *Room) MuteMember(userID string, until time.Time) error {
+	if r.IsCreator(userID) {
+		return errors.New("you are creator, cannot mute")
+	}
+	if r.IsGuest(userID) {
+		return errors.New("cannot mute guest")
+	}
+	defer r.invalidateMember(userID)
+	return db.SetRoomMemberMutedUntil(r.ID, userID, until.UnixMilli())
+}
+
+func (r *Room) UnmuteMember(userID string) error {
+	defer r.invalidateMember(userID)
+	return db.SetRoomMemberMutedUntil(r.ID, userID, 0)
+}
+
+// DeleteChatMessage deletes the message from the chat history
+// and tells the clients to retract it.
+func (r *Room) DeleteChatMessage(id uint64) error {
+	msg, err := db.GetChatMessage(r.ID, id)
+	if err != nil {
+		return err
+	}
+	// whispers are private to their participants
+	if msg.ReceiverID != "" {
+		return errors.New("cannot delete a whisper")
+	}
+	if err := db.DeleteChatMessage(r.ID, id); err != nil {
+		return err
+	}
+	return r.Broadcast(&pb.ElementMessage{
+		Type:            pb.ElementMessageType_CHAT_RETRACTED,
+		RetractedChatId: id,
+	})
+}
+
+// the room settings only changed by SetChatModeration,
+// which needs PermissionSetChatModeration instead of PermissionSetRoomSettings
+var chatModerationSettings = []string{"chat_slow_mode_interval", "chat_blocklist"}
+
+var ErrChatModerationSettings = errors.New("chat moderation settings can only be changed by the chat moderation api")
+
+func (r *Room) SetChatModeration(slowModeInterval int64, blocklist string) error {
+	return r.UpdateSettings(map[string]any{
+		"chat_slow_mode_interval": slowModeInterval,
+		"chat_blocklist":          blocklist,
+	})
+}
+
+func (u *User) MuteRoomMember(room *Room, userID string, duration time.Duration) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionMuteMember) {
+		return model.ErrNoPermission
+	}
+	if u.ID == userID {
+		return errors.New("cannot mute yourself")
+	}
+	if room.IsAdmin(userID) && !u.IsRoomCreator(room) {
+		return errors.New("cannot mute admin")
+	}
+	if duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	return room.MuteMember(userID, time.Now().Add(duration))
+}
+
+func (u *User) UnmuteRoomMember(room *Room, userID string) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionMuteMember) {
+		return model.ErrNoPermission
+	}
+	return room.UnmuteMember(userID)
+}
+
+func (u *User) DeleteRoomChatMessage(room *Room, id uint64) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionDeleteChatMessage) {
+		return model.ErrNoPermission
+	}
+	return room.DeleteChatMessage(id)
+}
+
+func (u *User) SetRoomChatModeration(room *Room, slowModeInterval int64, blocklist string) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionSetChatModeration) {
+		return model.ErrNoPermission
+	}
+	return room.SetChatModeration(slowModeInterval, blocklist)
+}
@@ -2,6 +2,8 @@ package op
 
 import (
 	"errors"
+	"math/rand"
+	"reflect"
 	"sync"
 	"time"
 
@@ -29,6 +31,34 @@ func (m *movies) init() {
 	})
 }
 
+// reload loads the movies again after they were changed on another node,
+// the movies that did not change keep their caches and channels.
+func (m *movies) reload() {
+	m.init()
+	list := db.GetAllMoviesByRoomID(m.roomID)
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	old := make(map[string]*Movie, m.list.Len())
+	for e := m.list.Front(); e != nil; e = e.Next() {
+		old[e.Value.Movie.ID] = e.Value
+	}
+	m.list.Clear()
+	for _, mo := range list {
+		if o, ok := old[mo.ID]; ok && reflect.DeepEqual(o.Movie.Base, mo.Base) {
+			delete(old, mo.ID)
+			o.Movie.Position = mo.Position
+			m.list.PushBack(o)
+			continue
+		}
+		m.list.PushBack(&Movie{
+			Movie: mo,
+		})
+	}
+	for _, o := range old {
+		_ = o.Terminate()
+	}
+}
+
 func (m *movies) Len() int {
 	m.init()
 	m.lock.RLock()
@@ -210,6 +240,48 @@ func (m *movies) getMovieElementByID(id string) (*dllist.Element[*Movie], error)
 	return nil, errors.New("movie not found")
 }
 
+// NextMovieID returns the movie after id in the list, empty if id is the last one
+func (m *movies) NextMovieID(id string) (string, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	e, err := m.getMovieElementByID(id)
+	if err != nil {
+		return "", err
+	}
+	if next := e.Next(); next != nil {
+		return next.Value.Movie.ID, nil
+	}
+	return "", nil
+}
+
+// FirstMovieID returns the first movie in the list, empty if the list is empty
+func (m *movies) FirstMovieID() string {
+	m.init()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if e := m.list.Front(); e != nil {
+		return e.Value.Movie.ID
+	}
+	return ""
+}
+
+// RandomMovieID returns a random movie other than exclude, empty if there is none
+func (m *movies) RandomMovieID(exclude string) string {
+	m.init()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	ids := make([]string, 0, m.list.Len())
+	for e := m.list.Front(); e != nil; e = e.Next() {
+		if e.Value.Movie.ID != exclude {
+			ids = append(ids, e.Value.Movie.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[rand.Intn(len(ids))]
+}
+
 func (m *movies) SwapMoviePositions(id1, id2 string) error {
 	m.init()
 	m.lock.Lock()
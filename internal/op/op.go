@@ -3,14 +3,37 @@ package op
 import (
 	"time"
 
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
 	"github.com/zijiren233/gencontainer/synccache"
 )
 
 func Init(size int) error {
 	roomCache = synccache.NewSyncCache[string, *Room](time.Minute*5, synccache.WithDeletedCallback[string, *Room](func(v *Room) {
+		if err := v.savePlayback(); err != nil {
+			log.Errorf("room %s: save playback error: %v", v.ID, err)
+		}
 		v.close()
 	}))
 	userCache = synccache.NewSyncCache[string, *User](time.Minute * 5)
+	subscribeControl()
+
+	go cleanExpiredChatMessages()
+	go runSchedules()
+	go flushPlaybacks()
+	go runWebhookEvents()
+	go runWebhookDeliveries()
+	go cleanOldWebhookDeliveries()
 
 	return nil
 }
+
+func cleanExpiredChatMessages() {
+	t := time.NewTicker(time.Hour)
+	defer t.Stop()
+	for range t.C {
+		if err := db.DeleteExpiredChatMessages(); err != nil {
+			log.Errorf("clean expired chat messages error: %v", err)
+		}
+	}
+}
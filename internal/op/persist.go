@@ -0,0 +1,74 @@
+package op
+
+import (
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	"github.com/zijiren233/gencontainer/synccache"
+)
+
+// how often the changed playback states of the rooms are persisted
+const playbackFlushInterval = 10 * time.Second
+
+// savePlayback persists the current movie and status if they changed on this node since the last save,
+// the other nodes only follow the change so the state is written by the node where it happened.
+// The seek is saved with the time it was taken, the restored one advances from it.
+func (r *Room) savePlayback() error {
+	c, ok := r.current.takeDirty()
+	if !ok {
+		return nil
+	}
+	err := db.SaveRoomPlayback(playbackOf(r.ID, c))
+	if err != nil {
+		var notFound db.ErrNotFound
+		if errors.As(err, &notFound) {
+			// the room has been deleted
+			return nil
+		}
+		r.current.setDirty()
+	}
+	return err
+}
+
+func playbackOf(roomID string, c Current) *model.RoomPlayback {
+	return &model.RoomPlayback{
+		RoomID:     roomID,
+		MovieID:    c.MovieID,
+		IsLive:     c.IsLive,
+		Seek:       c.Status.Seek,
+		Rate:       c.Status.Rate,
+		Playing:    c.Status.Playing,
+		LastUpdate: c.Status.lastUpdate,
+	}
+}
+
+// loadCurrent restores the persisted playback state of the room
+func loadCurrent(roomID string) *current {
+	p, err := db.GetRoomPlayback(roomID)
+	if err != nil {
+		return newCurrent()
+	}
+	return restoreCurrent(p)
+}
+
+// FlushPlaybacks persists the playback states of all rooms in memory changed on this node
+func FlushPlaybacks() error {
+	roomCache.Range(func(key string, value *synccache.Entry[*Room]) bool {
+		if err := value.Value().savePlayback(); err != nil {
+			log.Errorf("room %s: save playback error: %v", key, err)
+		}
+		return true
+	})
+	return nil
+}
+
+func flushPlaybacks() {
+	t := time.NewTicker(playbackFlushInterval)
+	defer t.Stop()
+	for range t.C {
+		_ = FlushPlaybacks()
+	}
+}
@@ -0,0 +1,126 @@
+package op
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/model"
+	pb "github.com/synctv-org/synctv/proto/message"
+)
+
+// a movie counts as ended this many seconds before its known duration
+const endedTolerance = 1.0
+
+// MovieEnded advances the room after movieID was played to the end, according to the playback mode.
+// Reports for a movie that is no longer the current one are ignored, so every client may report the end.
+func (r *Room) MovieEnded(movieID string, sender *pb.Sender) error {
+	r.advanceLock.Lock()
+	defer r.advanceLock.Unlock()
+
+	if movieID == "" || r.CurrentMovieID() != movieID {
+		return nil
+	}
+	var (
+		next string
+		err  error
+	)
+	switch r.Settings.PlaybackMode {
+	case model.PlaybackModeSequential:
+		next, err = r.movies.NextMovieID(movieID)
+	case model.PlaybackModeLoop:
+		next, err = r.movies.NextMovieID(movieID)
+		if next == "" {
+			next = r.movies.FirstMovieID()
+		}
+	case model.PlaybackModeShuffle:
+		next = r.movies.RandomMovieID(movieID)
+		if next == "" && !r.Settings.DeletePlayedMovies {
+			next = movieID
+		}
+	case model.PlaybackModeRepeatOne:
+		next = movieID
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = r.changeCurrentMovie(next, next != "", sender)
+	if err != nil {
+		return err
+	}
+	if !r.Settings.DeletePlayedMovies || next == movieID {
+		return nil
+	}
+	err = r.moviesChanged(r.movies.DeleteMovieByID(movieID))
+	if err != nil {
+		return err
+	}
+	return r.Broadcast(&pb.ElementMessage{
+		Type:          pb.ElementMessageType_MOVIES_CHANGED,
+		MoviesChanged: sender,
+	})
+}
+
+// currentChanged must be called whenever the current movie or its status changed on this node,
+// the end of the movie is scheduled here and the other nodes follow the new state.
+func (r *Room) currentChanged() {
+	r.scheduleEnded()
+	r.publishCurrent()
+}
+
+// scheduleEnded arms a timer for the end of the current movie if it is playing and its duration is known,
+// it is called by currentChanged and when the room is loaded.
+func (r *Room) scheduleEnded() {
+	r.endLock.Lock()
+	defer r.endLock.Unlock()
+
+	if r.endTimer != nil {
+		r.endTimer.Stop()
+		r.endTimer = nil
+	}
+	c := r.current.Current()
+	if c.MovieID == "" || c.IsLive || !c.Status.Playing || c.Status.Rate <= 0 {
+		return
+	}
+	m, err := r.GetMovieByID(c.MovieID)
+	if err != nil || m.Movie.Base.Duration <= 0 {
+		return
+	}
+	remaining := (m.Movie.Base.Duration - endedTolerance - c.Status.Seek) / c.Status.Rate
+	if remaining < 0 {
+		remaining = 0
+	}
+	movieID, duration := c.MovieID, m.Movie.Base.Duration
+	r.endTimer = time.AfterFunc(time.Duration(remaining*float64(time.Second)), func() {
+		// the timer may fire right before it is stopped
+		c := r.current.Current()
+		if c.MovieID != movieID || !c.Status.Playing || c.Status.Seek < duration-endedTolerance {
+			return
+		}
+		if err := r.MovieEnded(movieID, nil); err != nil {
+			log.Errorf("room %s: advance after movie %s ended error: %v", r.ID, movieID, err)
+		}
+	})
+}
+
+func (r *Room) stopEndTimer() {
+	r.endLock.Lock()
+	defer r.endLock.Unlock()
+	if r.endTimer != nil {
+		r.endTimer.Stop()
+		r.endTimer = nil
+	}
+}
+
+// ReportEnded is sent by a client whose player reached the end of movieID,
+// reports of members who cannot set the current movie are ignored.
+func (c *Client) ReportEnded(movieID string) error {
+	if !c.u.HasRoomPermission(c.r, model.PermissionSetCurrentMovie) {
+		return nil
+	}
+	return c.r.MovieEnded(movieID, &pb.Sender{
+		Username: c.u.Username,
+		Userid:   c.u.ID,
+	})
+}
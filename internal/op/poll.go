@@ -0,0 +1,407 @@
+package op
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/broadcaster"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	pb "github.com/synctv-org/synctv/proto/message"
+	"github.com/synctv-org/synctv/utils"
+	"google.golang.org/protobuf/proto"
+)
+
+type PollKind = string
+
+const (
+	// skip the current movie if the first option wins
+	PollKindSkip PollKind = "skip"
+	// a question with multiple choices
+	PollKindChoice PollKind = "choice"
+)
+
+const (
+	maxPollOptions = 10
+	maxPollTimeout = time.Minute * 10
+)
+
+var (
+	ErrNoActivePoll   = errors.New("no active poll")
+	ErrPollInProgress = errors.New("another poll is in progress")
+)
+
+type PollConf struct {
+	Question string
+	Options  []string
+	// zero means the room settings
+	Quorum  float64
+	Timeout time.Duration
+}
+
+// Poll is voted by the members online on any node,
+// an option wins as soon as it has the votes of the quorum of them.
+//
+// A poll runs on the node that started it, the other nodes mirror it
+// from its broadcasts and forward the votes and cancels of their clients to it.
+type Poll struct {
+	ID        string
+	Kind      PollKind
+	Question  string
+	Options   []string
+	Quorum    float64
+	CreatorID string
+	// the movie to skip
+	MovieID   string
+	ExpiresAt time.Time
+
+	r     *Room
+	lock  sync.Mutex
+	votes map[string]int
+	ended bool
+	// the winning option, -1 if none
+	result int
+	timer  *time.Timer
+	// the poll runs on another node
+	remote bool
+	// the last state broadcast by the node running a remote poll
+	mirror *pb.Poll
+}
+
+func (r *Room) StartPoll(creator *User, kind PollKind, conf PollConf) (*Poll, error) {
+	p := &Poll{
+		ID:        utils.SortUUID(),
+		Kind:      kind,
+		Question:  conf.Question,
+		Options:   conf.Options,
+		Quorum:    conf.Quorum,
+		CreatorID: creator.ID,
+		r:         r,
+		votes:     make(map[string]int),
+		result:    -1,
+	}
+	switch kind {
+	case PollKindSkip:
+		current := r.Current()
+		if current.MovieID == "" {
+			return nil, ErrNoCurrentMovie
+		}
+		p.MovieID = current.MovieID
+		p.Options = []string{"skip", "keep"}
+		if p.Question == "" {
+			p.Question = "skip the current movie?"
+			if m, err := r.GetMovieByID(current.MovieID); err == nil {
+				p.Question = "skip " + m.Movie.Base.Name + "?"
+			}
+		}
+	case PollKindChoice:
+		if p.Question == "" {
+			return nil, errors.New("question is empty")
+		}
+		if len(p.Options) < 2 || len(p.Options) > maxPollOptions {
+			return nil, errors.New("a poll needs 2 to 10 options")
+		}
+	default:
+		return nil, errors.New("unknown poll kind")
+	}
+	if p.Quorum == 0 {
+		p.Quorum = r.Settings.PollQuorum
+	}
+	if p.Quorum <= 0 || p.Quorum > 1 {
+		return nil, errors.New("quorum must be between 0 and 1")
+	}
+	timeout := conf.Timeout
+	if timeout == 0 {
+		timeout = time.Duration(r.Settings.PollTimeout) * time.Second
+	}
+	if timeout <= 0 || timeout > maxPollTimeout {
+		return nil, errors.New("poll timeout must be between 0 and 10 minutes")
+	}
+	p.ExpiresAt = time.Now().Add(timeout)
+
+	// receive the votes of the other nodes
+	r.lazyInitHub()
+	if err := r.hub.Start(); err != nil {
+		return nil, err
+	}
+	r.pollLock.Lock()
+	defer r.pollLock.Unlock()
+	if r.poll != nil && !r.poll.Ended() {
+		return nil, ErrPollInProgress
+	}
+	r.poll = p
+	p.timer = time.AfterFunc(timeout, func() {
+		if err := p.end(); err != nil {
+			log.Errorf("room: %s, end poll error: %v", r.ID, err)
+		}
+	})
+	return p, r.broadcastPoll(pb.ElementMessageType_POLL_STARTED, p.Proto())
+}
+
+// Poll returns the running poll or the last one, nil if no poll has been started
+func (r *Room) Poll() *Poll {
+	r.pollLock.Lock()
+	defer r.pollLock.Unlock()
+	return r.poll
+}
+
+func (r *Room) activePoll(id string) (*Poll, error) {
+	p := r.Poll()
+	if p == nil || p.ID != id || p.Ended() {
+		return nil, ErrNoActivePoll
+	}
+	return p, nil
+}
+
+func (r *Room) broadcastPoll(t pb.ElementMessageType, poll *pb.Poll) error {
+	return r.Broadcast(&pb.ElementMessage{
+		Type: t,
+		Poll: poll,
+	})
+}
+
+// mirrorPoll keeps the state of the poll running on another node,
+// a poll running on this node is not replaced by a concurrent one.
+func (r *Room) mirrorPoll(pp *pb.Poll) {
+	r.pollLock.Lock()
+	defer r.pollLock.Unlock()
+	if p := r.poll; p != nil && !p.remote && (p.ID == pp.Id || !p.Ended()) {
+		return
+	}
+	if p := r.poll; p != nil && p.ID == pp.Id {
+		p.lock.Lock()
+		p.mirror = pp
+		p.ended = pp.Ended
+		p.result = int(pp.Result)
+		p.lock.Unlock()
+		return
+	}
+	r.poll = &Poll{
+		ID:        pp.Id,
+		Kind:      pp.Kind,
+		Question:  pp.Question,
+		Options:   pp.Options,
+		Quorum:    pp.Quorum,
+		CreatorID: pp.GetCreator().GetUserid(),
+		MovieID:   pp.MovieId,
+		ExpiresAt: time.UnixMilli(pp.ExpiresAt),
+		r:         r,
+		ended:     pp.Ended,
+		result:    int(pp.Result),
+		remote:    true,
+		mirror:    pp,
+	}
+}
+
+// handleRemotePoll applies the votes and cancels forwarded by other nodes
+// to the poll running on this node.
+func (r *Room) handleRemotePoll(e *broadcaster.Event) {
+	p := r.Poll()
+	if p == nil || p.ID != e.PollID || p.remote {
+		return
+	}
+	var err error
+	switch e.Type {
+	case broadcaster.EventPollVote:
+		err = p.Vote(e.UserID, e.PollOption)
+	case broadcaster.EventPollCancel:
+		err = p.Cancel()
+	}
+	if err != nil && !errors.Is(err, ErrNoActivePoll) {
+		log.Errorf("room: %s, remote poll event error: %v", r.ID, err)
+	}
+}
+
+// forward sends the vote or cancel to the node running the poll
+func (p *Poll) forward(t broadcaster.EventType, userID string, option int) {
+	p.r.lazyInitHub()
+	p.r.hub.publishAsync(&broadcaster.Event{
+		Type:       t,
+		UserID:     userID,
+		PollID:     p.ID,
+		PollOption: option,
+	}, nil)
+}
+
+// the number of distinct users online on any node who can vote,
+// guests can not vote and are not counted
+func (r *Room) pollVoters() int64 {
+	r.lazyInitHub()
+	voters := make(map[string]struct{})
+	for _, id := range append(r.hub.localUserIDs(), r.hub.remoteUserIDs()...) {
+		if _, ok := voters[id]; ok || id == db.GuestUserID {
+			continue
+		}
+		if !r.HasPermission(id, model.PermissionSendChatMessage) {
+			continue
+		}
+		voters[id] = struct{}{}
+	}
+	return max(int64(len(voters)), 1)
+}
+
+// Ended reports whether the poll is over,
+// a mirrored poll is also over once expired in case its node is gone.
+func (p *Poll) Ended() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.ended || p.remote && time.Now().After(p.ExpiresAt)
+}
+
+// Vote casts or changes the vote of the user
+func (p *Poll) Vote(userID string, option int) error {
+	if option < 0 || option >= len(p.Options) {
+		return errors.New("invalid option")
+	}
+	if p.Ended() {
+		return ErrNoActivePoll
+	}
+	if p.remote {
+		p.forward(broadcaster.EventPollVote, userID, option)
+		return nil
+	}
+	p.lock.Lock()
+	if p.ended {
+		p.lock.Unlock()
+		return ErrNoActivePoll
+	}
+	p.votes[userID] = option
+	won := p.winner() != -1
+	p.lock.Unlock()
+	if won {
+		return p.end()
+	}
+	return p.r.broadcastPoll(pb.ElementMessageType_POLL_UPDATED, p.Proto())
+}
+
+// winner returns the option having the votes of the quorum, -1 if none
+func (p *Poll) winner() int {
+	need := int64(math.Ceil(p.Quorum * float64(p.r.pollVoters())))
+	for i, n := range p.counts() {
+		if n >= need {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *Poll) counts() []int64 {
+	counts := make([]int64, len(p.Options))
+	for _, o := range p.votes {
+		counts[o]++
+	}
+	return counts
+}
+
+// end closes the poll with the option winning now,
+// a won skip poll advances the room to the next movie.
+func (p *Poll) end() error {
+	return p.close(true)
+}
+
+// Cancel closes the poll without result
+func (p *Poll) Cancel() error {
+	if p.remote {
+		if p.Ended() {
+			return ErrNoActivePoll
+		}
+		p.forward(broadcaster.EventPollCancel, "", 0)
+		return nil
+	}
+	return p.close(false)
+}
+
+func (p *Poll) close(count bool) error {
+	p.lock.Lock()
+	if p.ended {
+		p.lock.Unlock()
+		return nil
+	}
+	p.ended = true
+	p.timer.Stop()
+	if count {
+		p.result = p.winner()
+	}
+	result := p.result
+	p.lock.Unlock()
+
+	if err := p.r.broadcastPoll(pb.ElementMessageType_POLL_ENDED, p.Proto()); err != nil {
+		return err
+	}
+	if p.Kind != PollKindSkip || result != 0 {
+		return nil
+	}
+	// the movie may have been changed while voting
+	if p.r.CurrentMovieID() != p.MovieID {
+		return nil
+	}
+	next, err := p.r.movies.NextMovieID(p.MovieID)
+	if err != nil {
+		return err
+	}
+	return p.r.changeCurrentMovie(next, next != "", &pb.Sender{
+		Userid:   p.CreatorID,
+		Username: GetUserName(p.CreatorID),
+	})
+}
+
+func (p *Poll) Proto() *pb.Poll {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.remote {
+		return proto.Clone(p.mirror).(*pb.Poll)
+	}
+	return &pb.Poll{
+		Id:        p.ID,
+		Kind:      p.Kind,
+		Question:  p.Question,
+		Options:   p.Options,
+		Votes:     p.counts(),
+		Voters:    p.r.pollVoters(),
+		Quorum:    p.Quorum,
+		ExpiresAt: p.ExpiresAt.UnixMilli(),
+		Creator: &pb.Sender{
+			Userid:   p.CreatorID,
+			Username: GetUserName(p.CreatorID),
+		},
+		MovieId: p.MovieID,
+		Result:  int32(p.result),
+		Ended:   p.ended,
+	}
+}
+
+func (u *User) StartRoomPoll(room *Room, kind PollKind, conf PollConf) (*Poll, error) {
+	if !u.HasRoomPermission(room, model.PermissionSendChatMessage) {
+		return nil, model.ErrNoPermission
+	}
+	return room.StartPoll(u, kind, conf)
+}
+
+// CancelRoomPoll cancels the running poll, allowed for its creator and room admins
+func (u *User) CancelRoomPoll(room *Room) error {
+	p := room.Poll()
+	if p == nil || p.Ended() {
+		return ErrNoActivePoll
+	}
+	if p.CreatorID != u.ID && !u.HasRoomAdminPermission(room, model.PermissionSetRoomSettings) {
+		return model.ErrNoPermission
+	}
+	return p.Cancel()
+}
+
+func (c *Client) VotePoll(pollID string, option int) error {
+	if c.u.IsGuest() {
+		return errors.New("guests cannot vote")
+	}
+	if !c.u.HasRoomPermission(c.r, model.PermissionSendChatMessage) {
+		return model.ErrNoPermission
+	}
+	p, err := c.r.activePoll(pollID)
+	if err != nil {
+		return err
+	}
+	return p.Vote(c.u.ID, option)
+}
@@ -0,0 +1,94 @@
+package op
+
+import (
+	"math"
+
+	pb "github.com/synctv-org/synctv/proto/message"
+)
+
+// Presence is the online status of a member on this node,
+// Status is the status of the member's device that checked last.
+//
+// The roster is node-local: the devices and statuses are the ones of the clients
+// connected to this node, a member connected to several nodes is listed by each of them.
+// PRESENCE_JOIN and PRESENCE_LEAVE are broadcast to every node and only sent when
+// the member joins or leaves the room as a whole, PRESENCE_UPDATE carries the view of
+// the node that sent it.
+type Presence struct {
+	UserID   string
+	Username string
+	Devices  int
+	Status   ClientStatus
+	Checked  bool
+}
+
+func (p *Presence) add(c *Client) {
+	p.Devices++
+	if s, ok := c.CheckStatus(); ok && (!p.Checked || s.CheckedAt.After(p.Status.CheckedAt)) {
+		p.Status = s
+		p.Checked = true
+	}
+}
+
+func (r *Room) inSync(drift float64) bool {
+	return math.Abs(drift) <= r.Settings.SyncNudgeThreshold
+}
+
+// Presences returns the roster of the members connected to this node
+func (r *Room) Presences() []*Presence {
+	m := make(map[string]*Presence)
+	list := make([]*Presence, 0)
+	r.RangeClients(func(c *Client) bool {
+		p, ok := m[c.u.ID]
+		if !ok {
+			p = &Presence{
+				UserID:   c.u.ID,
+				Username: c.u.Username,
+			}
+			m[c.u.ID] = p
+			list = append(list, p)
+		}
+		p.add(c)
+		return true
+	})
+	return list
+}
+
+func (r *Room) UserPresence(userID string) *Presence {
+	p := &Presence{
+		UserID:   userID,
+		Username: GetUserName(userID),
+	}
+	r.RangeClients(func(c *Client) bool {
+		if c.u.ID == userID {
+			p.add(c)
+		}
+		return true
+	})
+	return p
+}
+
+func (p *Presence) Proto() *pb.Presence {
+	resp := &pb.Presence{
+		User: &pb.Sender{
+			Userid:   p.UserID,
+			Username: p.Username,
+		},
+		Devices: int64(p.Devices),
+	}
+	if p.Checked {
+		resp.Playing = p.Status.Playing
+		resp.Seek = p.Status.Seek
+		resp.Buffering = p.Status.Buffering
+		resp.Drift = p.Status.Drift
+		resp.CheckedAt = p.Status.CheckedAt.UnixMilli()
+	}
+	return resp
+}
+
+func (r *Room) broadcastPresence(t pb.ElementMessageType, userID string) error {
+	return r.Broadcast(&pb.ElementMessage{
+		Type:     t,
+		Presence: r.UserPresence(userID).Proto(),
+	})
+}
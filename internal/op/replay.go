@@ -0,0 +1,74 @@
+package op
+
+import (
+	"hash/fnv"
+	"time"
+
+	pb "github.com/synctv-org/synctv/proto/message"
+)
+
+// less than the client channel size,
+// so replaying to a new client never blocks
+const replayBufferSize = 100
+
+// the high bits of a sequence identify the node that stamped it,
+// the sequences of different nodes are not comparable
+const replaySeqNodeShift = 48
+
+type replayMessage struct {
+	msg      *pb.ElementMessage
+	ignoreId []string
+	// the users whose client sent the message and did not get its echo,
+	// a resuming client of them does not get it either
+	senders []string
+	// set for a direct message, only replayed to this user
+	toUser string
+}
+
+// replayBuffer keeps the latest messages delivered by a hub,
+// so that a reconnecting client can get the messages it missed.
+//
+// Both broadcasts and direct messages (e.g. whispers) are buffered,
+// a direct message is only replayed to the clients of its recipient.
+// The buffer is node-local, a client resuming on another node
+// gets a resync instead.
+type replayBuffer struct {
+	node uint64
+	seq  uint64
+	msgs [replayBufferSize]replayMessage
+}
+
+func newReplayBuffer(nodeID string) *replayBuffer {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(nodeID))
+	node := uint64(h.Sum32()&0xffff) << replaySeqNodeShift
+	// start from the creation time, so the sequence of a recreated hub
+	// does not overlap the one its clients have seen before
+	return &replayBuffer{
+		node: node,
+		seq:  node | uint64(time.Now().Unix())<<16,
+	}
+}
+
+func (b *replayBuffer) push(m replayMessage) {
+	b.seq++
+	m.msg.Seq = b.seq
+	b.msgs[b.seq%replayBufferSize] = m
+}
+
+// since returns the messages after seq,
+// ok is false if some of them are no longer buffered
+// or seq was stamped by another node.
+func (b *replayBuffer) since(seq uint64) (msgs []replayMessage, ok bool) {
+	if seq>>replaySeqNodeShift != b.node>>replaySeqNodeShift {
+		return nil, false
+	}
+	if seq > b.seq || b.seq-seq > replayBufferSize {
+		return nil, false
+	}
+	msgs = make([]replayMessage, 0, b.seq-seq)
+	for s := seq + 1; s <= b.seq; s++ {
+		msgs = append(msgs, b.msgs[s%replayBufferSize])
+	}
+	return msgs, true
+}
@@ -4,12 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"hash/crc32"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
+	json "github.com/json-iterator/go"
+	"github.com/synctv-org/synctv/internal/broadcaster"
 	"github.com/synctv-org/synctv/internal/db"
 	"github.com/synctv-org/synctv/internal/model"
 	"github.com/synctv-org/synctv/internal/settings"
+	pb "github.com/synctv-org/synctv/proto/message"
 	"github.com/synctv-org/synctv/utils"
 	"github.com/zijiren233/gencontainer/rwmap"
 	rtmps "github.com/zijiren233/livelib/server"
@@ -25,14 +30,68 @@ type Room struct {
 	hub      *Hub
 	movies   movies
 	members  rwmap.RWMap[string, *model.RoomMember]
+	hostLock sync.RWMutex
+	hostID   string
+	// unix milliseconds when the host was set
+	hostAt int64
+
+	// the time of the last chat message of each member, for slow mode
+	lastChatAt    rwmap.RWMap[string, time.Time]
+	chatBlocklist atomic.Pointer[parsedBlocklist]
+
+	pollLock sync.Mutex
+	// the running poll or the last one
+	poll *Poll
+
+	// serializes advancing to the next movie after the current one ended
+	advanceLock sync.Mutex
+	endLock     sync.Mutex
+	// fires when the current movie reaches its known duration
+	endTimer *time.Timer
+
+	webhooks atomic.Pointer[cachedWebhooks]
+	roles    atomic.Pointer[cachedRoomRoles]
+
+	bufferingLock sync.Mutex
+	// the members buffering on other nodes, keyed by user and node, with the time it was reported
+	remoteBuffering map[string]map[string]time.Time
 }
 
 func (r *Room) lazyInitHub() {
 	r.initOnce.Do(func() {
 		r.hub = newHub(r.ID)
+		r.hub.onRemoteMessage = r.handleRemoteMessage
+		r.hub.hostState = r.hostState
+		r.hub.onRemoteHost = r.adoptHost
+		r.hub.onRemotePoll = r.handleRemotePoll
+		r.hub.onRemoteChat = r.handleRemoteChat
 	})
 }
 
+// handleRemoteMessage is called with the element messages broadcast by other nodes,
+// the current movie, the movies, the settings and the members changed on another node
+// are followed through the control channel instead, also by the nodes without clients of the room.
+func (r *Room) handleRemoteMessage(node string, msg *pb.ElementMessage) {
+	switch msg.Type {
+	case pb.ElementMessageType_HOST_CHANGED:
+		if msg.HostChanged != nil {
+			r.adoptHost(msg.HostChanged.Userid, msg.Time)
+		}
+	case pb.ElementMessageType_POLL_STARTED,
+		pb.ElementMessageType_POLL_UPDATED,
+		pb.ElementMessageType_POLL_ENDED:
+		if msg.Poll != nil {
+			r.mirrorPoll(msg.Poll)
+		}
+	case pb.ElementMessageType_PRESENCE_JOIN,
+		pb.ElementMessageType_PRESENCE_UPDATE,
+		pb.ElementMessageType_PRESENCE_LEAVE:
+		if msg.Presence != nil {
+			r.followRemoteBuffering(node, msg.Type, msg.Presence)
+		}
+	}
+}
+
 func (r *Room) PeopleNum() int64 {
 	if r.hub == nil {
 		return 0
@@ -42,21 +101,41 @@ func (r *Room) PeopleNum() int64 {
 
 func (r *Room) KickUser(userID string) error {
 	if r.hub == nil {
-		return nil
+		return publishRoomEvent(r.ID, &broadcaster.Event{
+			Type:   broadcaster.EventKickUser,
+			UserID: userID,
+		}, nil)
 	}
 	return r.hub.KickUser(userID)
 }
 
 func (r *Room) Broadcast(data Message, conf ...BroadcastConf) error {
+	if em, ok := data.(*pb.ElementMessage); ok {
+		r.broadcastWebhook(em)
+	}
 	if r.hub == nil {
-		return nil
+		bm := &broadcastMessage{}
+		for _, c := range conf {
+			c(bm)
+		}
+		if bm.localOnly {
+			// no client on this node
+			return nil
+		}
+		return publishRoomEvent(r.ID, &broadcaster.Event{
+			Type:      broadcaster.EventBroadcast,
+			IgnoreIDs: bm.ignoreId,
+		}, data)
 	}
 	return r.hub.Broadcast(data, conf...)
 }
 
 func (r *Room) SendToUser(user *User, data Message) error {
 	if r.hub == nil {
-		return nil
+		return publishRoomEvent(r.ID, &broadcaster.Event{
+			Type:   broadcaster.EventSendToUser,
+			UserID: user.ID,
+		}, data)
 	}
 	return r.hub.SendToUser(user.ID, data)
 }
@@ -66,12 +145,18 @@ func (r *Room) GetChannel(channelName string) (*rtmps.Channel, error) {
 }
 
 func (r *Room) close() {
+	r.stopEndTimer()
 	if r.initOnce.Done() {
 		r.hub.Close()
 		r.movies.Close()
 	}
 }
 
+// roomVersion is carried by the room tokens, it changes with the password and the creator
+func roomVersion(hashedPassword []byte, creatorID string) uint32 {
+	return crc32.Update(crc32.ChecksumIEEE(hashedPassword), crc32.IEEETable, stream.StringToBytes(creatorID))
+}
+
 func (r *Room) Version() uint32 {
 	return atomic.LoadUint32(&r.version)
 }
@@ -84,19 +169,28 @@ func (r *Room) UpdateMovie(movieId string, movie *model.BaseMovie) error {
 	if r.current.current.MovieID == movieId {
 		return errors.New("cannot update current movie")
 	}
-	return r.movies.Update(movieId, movie)
+	return r.moviesChanged(r.movies.Update(movieId, movie))
 }
 
 func (r *Room) AddMovie(m *model.Movie) error {
 	m.RoomID = r.ID
-	return r.movies.AddMovie(m)
+	return r.moviesChanged(r.movies.AddMovie(m))
 }
 
 func (r *Room) AddMovies(movies []*model.Movie) error {
 	for _, m := range movies {
 		m.RoomID = r.ID
 	}
-	return r.movies.AddMovies(movies)
+	return r.moviesChanged(r.movies.AddMovies(movies))
+}
+
+// moviesChanged tells the other nodes to reload the movies unless changing them failed
+func (r *Room) moviesChanged(err error) error {
+	if err != nil {
+		return err
+	}
+	r.invalidatePeers(broadcaster.EventInvalidateRoomMovies)
+	return nil
 }
 
 func (r *Room) UserRole(userID string) (model.RoomMemberRole, error) {
@@ -151,8 +245,30 @@ func (r *Room) HasPermission(userID string, permission model.RoomMemberPermissio
 		permission.Has(model.PermissionSendChatMessage) && !r.Settings.CanSendChatMessage:
 		return false
 	default:
-		return rur.Permissions.Has(permission)
+		return r.MemberPermissions(rur).Has(permission)
+	}
+}
+
+// memberPermissions returns the permissions of the member together with the ones granted by its roles
+func (r *Room) MemberPermissions(rur *model.RoomMember) model.RoomMemberPermission {
+	if rur.Status.IsNotActive() {
+		return rur.Permissions
+	}
+	permissions, _ := r.rolePermissions(rur.UserID)
+	return rur.Permissions.Add(permissions)
+}
+
+// memberAdminPermissions returns the admin permissions of the member together with the ones granted by its roles
+func (r *Room) MemberAdminPermissions(rur *model.RoomMember) model.RoomAdminPermission {
+	var adminPermissions model.RoomAdminPermission
+	if rur.Role.IsAdmin() {
+		adminPermissions = rur.AdminPermissions
+	}
+	if rur.Status.IsNotActive() {
+		return adminPermissions
 	}
+	_, rolePermissions := r.rolePermissions(rur.UserID)
+	return adminPermissions.Add(rolePermissions)
 }
 
 func (r *Room) HasAdminPermission(userID string, permission model.RoomAdminPermission) bool {
@@ -165,7 +281,13 @@ func (r *Room) HasAdminPermission(userID string, permission model.RoomAdminPermi
 		return false
 	}
 
-	return rur.HasAdminPermission(permission)
+	if rur.HasAdminPermission(permission) {
+		return true
+	}
+	if rur.Status.IsNotActive() {
+		return false
+	}
+	return r.MemberAdminPermissions(rur).Has(permission)
 }
 
 func (r *Room) LoadOrCreateMemberStatus(userID string) (model.RoomMemberStatus, error) {
@@ -232,10 +354,15 @@ func (r *Room) LoadOrCreateRoomMember(userID string) (*model.RoomMember, error)
 			conf = append(conf, db.WithRoomMemberStatus(model.RoomMemberStatusActive))
 		}
 	}
-	member, err := db.FirstOrCreateRoomMemberRelation(r.ID, userID, conf...)
+	member, created, err := db.FirstOrCreateRoomMemberRelation(r.ID, userID, conf...)
 	if err != nil {
 		return nil, err
 	}
+	if created {
+		r.emitWebhook(model.WebhookEventMemberJoined, WebhookData{
+			Member: webhookUserByID(userID),
+		})
+	}
 	if r.IsCreator(userID) {
 		member.Role = model.RoomMemberRoleCreator
 		member.Permissions = model.AllPermissions
@@ -294,7 +421,7 @@ func (r *Room) LoadRoomMemberPermission(userID string) (model.RoomMemberPermissi
 	if err != nil {
 		return model.NoPermission, err
 	}
-	return member.Permissions, nil
+	return r.MemberPermissions(member), nil
 }
 
 func (r *Room) LoadRoomAdminPermission(userID string) (model.RoomAdminPermission, error) {
@@ -305,7 +432,7 @@ func (r *Room) LoadRoomAdminPermission(userID string) (model.RoomAdminPermission
 	if err != nil {
 		return model.NoAdminPermission, err
 	}
-	return member.AdminPermissions, nil
+	return r.MemberAdminPermissions(member), nil
 }
 
 func (r *Room) NeedPassword() bool {
@@ -323,7 +450,7 @@ func (r *Room) SetPassword(password string) error {
 		if err != nil {
 			return err
 		}
-		atomic.StoreUint32(&r.version, crc32.ChecksumIEEE(hashedPassword))
+		atomic.StoreUint32(&r.version, roomVersion(hashedPassword, r.CreatorID))
 	}
 	r.HashedPassword = hashedPassword
 	return db.SetRoomHashedPassword(r.ID, hashedPassword)
@@ -337,7 +464,7 @@ func (r *Room) DeleteMovieByID(id string) error {
 	if r.current.current.MovieID == id {
 		return errors.New("cannot delete current movie")
 	}
-	return r.movies.DeleteMovieByID(id)
+	return r.moviesChanged(r.movies.DeleteMovieByID(id))
 }
 
 func (r *Room) DeleteMoviesByID(ids []string) error {
@@ -348,12 +475,12 @@ func (r *Room) DeleteMoviesByID(ids []string) error {
 			}
 		}
 	}
-	return r.movies.DeleteMoviesByID(ids)
+	return r.moviesChanged(r.movies.DeleteMoviesByID(ids))
 }
 
 func (r *Room) ClearMovies() error {
 	_ = r.SetCurrentMovie("", false)
-	return r.movies.Clear()
+	return r.moviesChanged(r.movies.Clear())
 }
 
 func (r *Room) GetMovieByID(id string) (*Movie, error) {
@@ -389,6 +516,7 @@ func (r *Room) CheckCurrentExpired(expireId uint64) (bool, error) {
 func (r *Room) SetCurrentMovie(movieID string, play bool) error {
 	if movieID == "" {
 		r.current.SetMovie("", false, play)
+		r.currentChanged()
 		return nil
 	}
 	m, err := r.GetMovieByID(movieID)
@@ -396,27 +524,77 @@ func (r *Room) SetCurrentMovie(movieID string, play bool) error {
 		return err
 	}
 	r.current.SetMovie(m.ID, m.Base.Live, play)
+	r.currentChanged()
 	return nil
 }
 
+// changeCurrentMovie sets the current movie and tells the clients who changed it
+func (r *Room) changeCurrentMovie(movieID string, play bool, sender *pb.Sender) error {
+	err := r.SetCurrentMovie(movieID, play)
+	if err != nil {
+		return err
+	}
+	return r.Broadcast(&pb.ElementMessage{
+		Type:           pb.ElementMessageType_CURRENT_CHANGED,
+		CurrentChanged: sender,
+	})
+}
+
 func (r *Room) SwapMoviePositions(id1, id2 string) error {
-	return r.movies.SwapMoviePositions(id1, id2)
+	return r.moviesChanged(r.movies.SwapMoviePositions(id1, id2))
 }
 
 func (r *Room) GetMoviesWithPage(page, pageSize int, creator string) ([]*Movie, int) {
 	return r.movies.GetMoviesWithPage(page, pageSize, creator)
 }
 
-func (r *Room) NewClient(user *User, conn *websocket.Conn) (*Client, error) {
+// NewClient registers a client of the connection,
+// a client without connection is read-only, it only receives the messages and is not online, e.g. for an event stream.
+func (r *Room) NewClient(user *User, conn *websocket.Conn, conf ...ClientConf) (*Client, error) {
 	r.lazyInitHub()
-	cli := newClient(user, r, conn)
+	cli := newClient(user, r, conn, conf...)
 	err := r.hub.RegClient(cli)
 	if err != nil {
 		return nil, err
 	}
+	if cli.ReadOnly() {
+		return cli, nil
+	}
+	r.broadcastJoined(user.ID)
+	r.claimVacantHost(user)
 	return cli, nil
 }
 
+// ResumeClient is NewClient for a reconnecting client that has seen the messages up to seq,
+// the missed ones are replayed, or RESYNC is sent if they are no longer buffered.
+func (r *Room) ResumeClient(user *User, conn *websocket.Conn, seq uint64, conf ...ClientConf) (cli *Client, resumed bool, err error) {
+	r.lazyInitHub()
+	cli = newClient(user, r, conn, conf...)
+	resumed, err = r.hub.ResumeClient(cli, seq)
+	if err != nil {
+		return nil, false, err
+	}
+	if !resumed {
+		_ = cli.Send(&pb.ElementMessage{
+			Type: pb.ElementMessageType_RESYNC,
+		})
+	}
+	if cli.ReadOnly() {
+		return cli, resumed, nil
+	}
+	r.broadcastJoined(user.ID)
+	r.claimVacantHost(user)
+	return cli, resumed, nil
+}
+
+func (r *Room) broadcastJoined(userID string) {
+	if r.hub.OnlineCount(userID) == 1 && !utils.In(r.hub.remoteUserIDs(), userID) {
+		_ = r.broadcastPresence(pb.ElementMessageType_PRESENCE_JOIN, userID)
+	} else {
+		_ = r.broadcastPresence(pb.ElementMessageType_PRESENCE_UPDATE, userID)
+	}
+}
+
 func (r *Room) RegClient(cli *Client) error {
 	r.lazyInitHub()
 	return r.hub.RegClient(cli)
@@ -424,7 +602,31 @@ func (r *Room) RegClient(cli *Client) error {
 
 func (r *Room) UnregisterClient(cli *Client) error {
 	r.lazyInitHub()
-	return r.hub.UnRegClient(cli)
+	err := r.hub.UnRegClient(cli)
+	if err != nil {
+		return err
+	}
+	if cli.ReadOnly() {
+		return nil
+	}
+	if !r.hub.IsOnline(cli.u.ID) {
+		r.sweepLastChatAt()
+	}
+	if r.hub.IsOnlineAnywhere(cli.u.ID) {
+		_ = r.broadcastPresence(pb.ElementMessageType_PRESENCE_UPDATE, cli.u.ID)
+		if r.hub.IsOnline(cli.u.ID) {
+			return nil
+		}
+		// the member left this node, there may be nobody buffering here anymore
+		return r.checkBuffering(nil)
+	}
+	_ = r.broadcastPresence(pb.ElementMessageType_PRESENCE_LEAVE, cli.u.ID)
+	// the member may be the last one the room was waiting for
+	_ = r.checkBuffering(nil)
+	if r.IsHost(cli.u.ID) && !r.hub.IsOnlineAnywhere(cli.u.ID) {
+		return r.reassignHost()
+	}
+	return nil
 }
 
 func (r *Room) UserIsOnline(userID string) bool {
@@ -437,20 +639,88 @@ func (r *Room) UserOnlineCount(userID string) int {
 	return r.hub.OnlineCount(userID)
 }
 
+// RangeClients only ranges the clients connected to this node,
+// read-only clients are skipped.
+func (r *Room) RangeClients(f func(c *Client) bool) {
+	if r.hub == nil {
+		return
+	}
+	r.hub.RangeClients(func(c *Client) bool {
+		if c.ReadOnly() {
+			return true
+		}
+		return f(c)
+	})
+}
+
 func (r *Room) SetCurrentStatus(playing bool, seek float64, rate float64, timeDiff float64) *Status {
-	return r.current.SetStatus(playing, seek, rate, timeDiff)
+	s := r.current.SetStatus(playing, seek, rate, timeDiff)
+	r.currentChanged()
+	return s
 }
 
 func (r *Room) SetCurrentSeekRate(seek float64, rate float64, timeDiff float64) *Status {
-	return r.current.SetSeekRate(seek, rate, timeDiff)
+	s := r.current.SetSeekRate(seek, rate, timeDiff)
+	r.currentChanged()
+	return s
+}
+
+func validateSettings(s *model.RoomSettings) error {
+	if s.SyncNudgeThreshold <= 0 {
+		return errors.New("sync nudge threshold must be greater than 0")
+	}
+	if s.SyncSeekThreshold <= s.SyncNudgeThreshold {
+		return errors.New("sync seek threshold must be greater than the nudge threshold")
+	}
+	if s.ChatSlowModeInterval < 0 {
+		return errors.New("slow mode interval cannot be negative")
+	}
+	if s.PollQuorum <= 0 || s.PollQuorum > 1 {
+		return errors.New("poll quorum must be between 0 and 1")
+	}
+	// in seconds, so a huge value cannot overflow the duration
+	if s.PollTimeout <= 0 || s.PollTimeout > int64(maxPollTimeout/time.Second) {
+		return errors.New("poll timeout must be between 0 and 10 minutes")
+	}
+	if _, err := utils.ParseBlocklist(s.ChatBlocklist); err != nil {
+		return err
+	}
+	return nil
+}
+
+// mergeSettings returns the settings with the update applied, to validate them before saving
+func mergeSettings(s *model.RoomSettings, update map[string]any) (*model.RoomSettings, error) {
+	m := map[string]any{}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	for k, v := range update {
+		m[k] = v
+	}
+	if b, err = json.Marshal(m); err != nil {
+		return nil, err
+	}
+	merged := &model.RoomSettings{}
+	if err := json.Unmarshal(b, merged); err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+	return merged, nil
 }
 
 func (r *Room) SetSettings(settings *model.RoomSettings) error {
+	if err := validateSettings(settings); err != nil {
+		return err
+	}
 	err := db.SaveRoomSettings(r.ID, settings)
 	if err != nil {
 		return err
 	}
 	r.Settings = settings
+	r.invalidatePeers(broadcaster.EventInvalidateRoomSettings)
 	if settings.DisableGuest {
 		return r.KickUser(db.GuestUserID)
 	}
@@ -458,11 +728,19 @@ func (r *Room) SetSettings(settings *model.RoomSettings) error {
 }
 
 func (r *Room) UpdateSettings(settings map[string]any) error {
+	merged, err := mergeSettings(r.Settings, settings)
+	if err != nil {
+		return err
+	}
+	if err := validateSettings(merged); err != nil {
+		return err
+	}
 	rs, err := db.UpdateRoomSettings(r.ID, settings)
 	if err != nil {
 		return err
 	}
 	r.Settings = rs
+	r.invalidatePeers(broadcaster.EventInvalidateRoomSettings)
 	if rs.DisableGuest {
 		return r.KickUser(db.GuestUserID)
 	}
@@ -480,7 +758,7 @@ func (r *Room) SetMemberPermissions(userID string, permissions model.RoomMemberP
 	if r.IsGuest(userID) {
 		return errors.New("cannot set permissions to guest")
 	}
-	defer r.members.Delete(userID)
+	defer r.invalidateMember(userID)
 	return db.SetMemberPermissions(r.ID, userID, permissions)
 }
 
@@ -491,7 +769,7 @@ func (r *Room) AddMemberPermissions(userID string, permissions model.RoomMemberP
 	if r.IsAdmin(userID) {
 		return errors.New("cannot add permissions to admin")
 	}
-	defer r.members.Delete(userID)
+	defer r.invalidateMember(userID)
 	return db.AddMemberPermissions(r.ID, userID, permissions)
 }
 
@@ -499,7 +777,7 @@ func (r *Room) RemoveMemberPermissions(userID string, permissions model.RoomMemb
 	if r.IsAdmin(userID) {
 		return errors.New("cannot remove permissions from admin")
 	}
-	defer r.members.Delete(userID)
+	defer r.invalidateMember(userID)
 	return db.RemoveMemberPermissions(r.ID, userID, permissions)
 }
 
@@ -507,7 +785,7 @@ func (r *Room) ApprovePendingMember(userID string) error {
 	if r.IsCreator(userID) {
 		return errors.New("you are creator, cannot approve")
 	}
-	defer r.members.Delete(userID)
+	defer r.invalidateMember(userID)
 	return db.RoomApprovePendingMember(r.ID, userID)
 }
 
@@ -519,17 +797,22 @@ func (r *Room) BanMember(userID string) error {
 		return errors.New("cannot ban guest")
 	}
 	defer func() {
-		r.members.Delete(userID)
+		r.invalidateMember(userID)
 		_ = r.KickUser(userID)
 	}()
-	return db.RoomBanMember(r.ID, userID)
+	if err := db.RoomBanMember(r.ID, userID); err != nil {
+		return err
+	}
+	// the roles of the member are removed with the ban
+	r.invalidateRoles()
+	return nil
 }
 
 func (r *Room) UnbanMember(userID string) error {
 	if r.IsCreator(userID) {
 		return errors.New("you are creator, cannot unban")
 	}
-	defer r.members.Delete(userID)
+	defer r.invalidateMember(userID)
 	return db.RoomUnbanMember(r.ID, userID)
 }
 
@@ -549,7 +832,7 @@ func (r *Room) SetAdminPermissions(userID string, permissions model.RoomAdminPer
 	} else if !member.Role.IsAdmin() {
 		return errors.New("not admin")
 	}
-	defer r.members.Delete(userID)
+	defer r.invalidateMember(userID)
 	return db.RoomSetAdminPermissions(r.ID, userID, permissions)
 }
 
@@ -565,7 +848,7 @@ func (r *Room) AddAdminPermissions(userID string, permissions model.RoomAdminPer
 	} else if !member.Role.IsAdmin() {
 		return errors.New("not admin")
 	}
-	defer r.members.Delete(userID)
+	defer r.invalidateMember(userID)
 	return db.RoomSetAdminPermissions(r.ID, userID, permissions)
 }
 
@@ -581,7 +864,7 @@ func (r *Room) RemoveAdminPermissions(userID string, permissions model.RoomAdmin
 	} else if !member.Role.IsAdmin() {
 		return errors.New("not admin")
 	}
-	defer r.members.Delete(userID)
+	defer r.invalidateMember(userID)
 	return db.RoomSetAdminPermissions(r.ID, userID, 0)
 }
 
@@ -592,7 +875,7 @@ func (r *Room) SetAdmin(userID string, permissions model.RoomAdminPermission) er
 	if r.IsGuest(userID) {
 		return errors.New("cannot set guest as admin")
 	}
-	defer r.members.Delete(userID)
+	defer r.invalidateMember(userID)
 	return db.RoomSetAdmin(r.ID, userID, permissions)
 }
 
@@ -600,6 +883,6 @@ func (r *Room) SetMember(userID string, permissions model.RoomMemberPermission)
 	if r.IsCreator(userID) {
 		return errors.New("you are creator, cannot set member")
 	}
-	defer r.members.Delete(userID)
+	defer r.invalidateMember(userID)
 	return db.RoomSetMember(r.ID, userID, permissions)
 }
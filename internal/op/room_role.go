@@ -0,0 +1,174 @@
+package op
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/broadcaster"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+)
+
+// the roles may be changed on another node, so the cached ones expire
+const roomRoleCacheTTL = time.Minute
+
+type cachedRoomRoles struct {
+	list []*model.RoomRole
+	// the roles held by each member
+	byUser   map[string][]*model.RoomRole
+	loadedAt time.Time
+}
+
+func (r *Room) loadRoles() *cachedRoomRoles {
+	if c := r.roles.Load(); c != nil && time.Since(c.loadedAt) < roomRoleCacheTTL {
+		return c
+	}
+	list, err := db.GetRoomRoles(r.ID)
+	if err != nil {
+		log.Errorf("room %s: load roles error: %v", r.ID, err)
+		return &cachedRoomRoles{}
+	}
+	c := &cachedRoomRoles{
+		list:     list,
+		byUser:   make(map[string][]*model.RoomRole),
+		loadedAt: time.Now(),
+	}
+	for _, role := range list {
+		for _, m := range role.Members {
+			c.byUser[m.UserID] = append(c.byUser[m.UserID], role)
+		}
+	}
+	r.roles.Store(c)
+	return c
+}
+
+// invalidateRoles drops the cached roles of the room on every node
+func (r *Room) invalidateRoles() {
+	r.roles.Store(nil)
+	publishControlEvent(&broadcaster.Event{
+		Type:   broadcaster.EventInvalidateRoomRoles,
+		RoomID: r.ID,
+	})
+}
+
+func (r *Room) Roles() []*model.RoomRole {
+	return r.loadRoles().list
+}
+
+func (r *Room) MemberRoles(userID string) []*model.RoomRole {
+	return r.loadRoles().byUser[userID]
+}
+
+// rolePermissions returns the permissions granted by the roles of the member
+func (r *Room) rolePermissions(userID string) (model.RoomMemberPermission, model.RoomAdminPermission) {
+	var (
+		permissions      model.RoomMemberPermission
+		adminPermissions model.RoomAdminPermission
+	)
+	for _, role := range r.MemberRoles(userID) {
+		permissions = permissions.Add(role.Permissions)
+		adminPermissions = adminPermissions.Add(role.AdminPermissions)
+	}
+	return permissions, adminPermissions
+}
+
+// IsRoleAdmin reports whether the roles of the active member grant any admin permission
+func (r *Room) IsRoleAdmin(userID string) bool {
+	_, adminPermissions := r.rolePermissions(userID)
+	if adminPermissions == model.NoAdminPermission {
+		return false
+	}
+	status, err := r.LoadMemberStatus(userID)
+	return err == nil && status.IsActive()
+}
+
+func (u *User) CreateRoomRole(room *Room, name string, permissions model.RoomMemberPermission, adminPermissions model.RoomAdminPermission) (*model.RoomRole, error) {
+	if !u.IsRoomCreator(room) {
+		return nil, model.ErrNoPermission
+	}
+	role := &model.RoomRole{
+		RoomID:           room.ID,
+		Name:             name,
+		Permissions:      permissions,
+		AdminPermissions: adminPermissions,
+	}
+	if err := db.CreateRoomRole(role); err != nil {
+		return nil, err
+	}
+	room.invalidateRoles()
+	room.audit(u, model.AuditActionRoleCreate, role.ID, nil, newAuditRole(role))
+	return role, nil
+}
+
+// UpdateRoomRole changes the role for all the members holding it
+func (u *User) UpdateRoomRole(room *Room, id, name string, permissions model.RoomMemberPermission, adminPermissions model.RoomAdminPermission) (*model.RoomRole, error) {
+	if !u.IsRoomCreator(room) {
+		return nil, model.ErrNoPermission
+	}
+	old, err := db.GetRoomRole(room.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	role := *old
+	role.Name = name
+	role.Permissions = permissions
+	role.AdminPermissions = adminPermissions
+	if err := db.UpdateRoomRole(&role); err != nil {
+		return nil, err
+	}
+	room.invalidateRoles()
+	room.audit(u, model.AuditActionRoleUpdate, id, newAuditRole(old), newAuditRole(&role))
+	return &role, nil
+}
+
+func (u *User) DeleteRoomRole(room *Room, id string) error {
+	if !u.IsRoomCreator(room) {
+		return model.ErrNoPermission
+	}
+	old, err := db.GetRoomRole(room.ID, id)
+	if err != nil {
+		return err
+	}
+	if err := db.DeleteRoomRole(room.ID, id); err != nil {
+		return err
+	}
+	room.invalidateRoles()
+	room.audit(u, model.AuditActionRoleDelete, id, newAuditRole(old), nil)
+	return nil
+}
+
+// SetRoomMemberRoles replaces the roles held by the member
+func (u *User) SetRoomMemberRoles(room *Room, userID string, roleIDs []string) error {
+	if !u.IsRoomCreator(room) {
+		return model.ErrNoPermission
+	}
+	if room.IsCreator(userID) {
+		return errors.New("cannot set roles of creator")
+	}
+	if room.IsGuest(userID) {
+		return errors.New("cannot set roles of guest")
+	}
+	if _, err := room.LoadRoomMember(userID); err != nil {
+		return err
+	}
+	roleIDs = slices.Clone(roleIDs)
+	slices.Sort(roleIDs)
+	roleIDs = slices.Compact(roleIDs)
+	old := memberRoleIDs(room.MemberRoles(userID))
+	if err := db.SetRoomMemberRoles(room.ID, userID, roleIDs); err != nil {
+		return err
+	}
+	room.invalidateRoles()
+	room.audit(u, model.AuditActionMemberRoles, userID, old, roleIDs)
+	return nil
+}
+
+func memberRoleIDs(roles []*model.RoomRole) []string {
+	ids := make([]string, len(roles))
+	for i, role := range roles {
+		ids[i] = role.ID
+	}
+	return ids
+}
@@ -3,7 +3,6 @@ package op
 import (
 	"errors"
 	"fmt"
-	"hash/crc32"
 	"time"
 
 	"github.com/synctv-org/synctv/internal/db"
@@ -63,14 +62,18 @@ func LoadOrInitRoom(room *model.Room) (*RoomEntry, error) {
 		return nil, err
 	}
 
-	i, _ := roomCache.LoadOrStore(room.ID, &Room{
+	i, loaded := roomCache.LoadOrStore(room.ID, &Room{
 		Room:    *room,
-		version: crc32.ChecksumIEEE(room.HashedPassword),
-		current: newCurrent(),
+		version: roomVersion(room.HashedPassword, room.CreatorID),
+		current: loadCurrent(room.ID),
 		movies: movies{
 			roomID: room.ID,
 		},
 	}, time.Duration(settings.RoomTTL.Get())*time.Hour)
+	if !loaded {
+		// the restored movie may be playing
+		i.Value().scheduleEnded()
+	}
 	return i, nil
 }
 
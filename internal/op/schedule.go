@@ -0,0 +1,166 @@
+package op
+
+import (
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	pb "github.com/synctv-org/synctv/proto/message"
+	"github.com/zijiren233/gencontainer/rwmap"
+)
+
+const (
+	// clients get the countdown this long before a schedule starts
+	scheduleCountdown = 10 * time.Second
+	// a schedule missed by more than this, e.g. while the server was down, is dropped
+	scheduleGrace = 5 * time.Minute
+)
+
+var ErrScheduleInPast = errors.New("schedule start time is in the past")
+
+// the timers of the schedules starting soon, nil while being armed
+var armedSchedules rwmap.RWMap[string, *time.Timer]
+
+func runSchedules() {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	for range t.C {
+		list, err := db.GetSchedulesBefore(time.Now().Add(scheduleCountdown))
+		if err != nil {
+			log.Errorf("load schedules error: %v", err)
+			continue
+		}
+		for _, s := range list {
+			armSchedule(s)
+		}
+	}
+}
+
+func armSchedule(s *model.RoomSchedule) {
+	if _, loaded := armedSchedules.LoadOrStore(s.ID, nil); loaded {
+		return
+	}
+	if time.Since(s.StartAt) > scheduleGrace {
+		log.Warnf("room %s: drop schedule %s missed at %v", s.RoomID, s.ID, s.StartAt)
+		_ = db.DeleteRoomSchedule(s.RoomID, s.ID)
+		armedSchedules.Delete(s.ID)
+		return
+	}
+	timer := time.AfterFunc(time.Until(s.StartAt), func() {
+		fireSchedule(s)
+	})
+	if !armedSchedules.CompareAndSwap(s.ID, nil, timer) {
+		// canceled meanwhile
+		timer.Stop()
+		return
+	}
+	// every node arms the schedule, so each one only tells its own clients
+	if e, ok := roomCache.Load(s.RoomID); ok {
+		_ = e.Value().Broadcast(&pb.ElementMessage{
+			Type:     pb.ElementMessageType_SCHEDULE_COUNTDOWN,
+			Schedule: scheduleProto(s),
+		}, withLocalOnly())
+	}
+}
+
+func fireSchedule(s *model.RoomSchedule) {
+	defer armedSchedules.Delete(s.ID)
+	// claims the schedule, it may have been canceled or fired by another node
+	if err := db.DeleteRoomSchedule(s.RoomID, s.ID); err != nil {
+		return
+	}
+	e, err := LoadOrInitRoomByID(s.RoomID)
+	if err != nil {
+		log.Errorf("room %s: load room for schedule %s error: %v", s.RoomID, s.ID, err)
+		return
+	}
+	r := e.Value()
+	sender := &pb.Sender{
+		Userid:   s.CreatorID,
+		Username: GetUserName(s.CreatorID),
+	}
+	if err := r.changeCurrentMovie(s.MovieID, true, sender); err != nil {
+		log.Errorf("room %s: start schedule %s error: %v", s.RoomID, s.ID, err)
+	}
+	_ = r.broadcastSchedulesChanged(sender)
+}
+
+func scheduleProto(s *model.RoomSchedule) *pb.Schedule {
+	return &pb.Schedule{
+		Id:      s.ID,
+		MovieId: s.MovieID,
+		Title:   s.Title,
+		StartAt: s.StartAt.UnixMilli(),
+		Creator: &pb.Sender{
+			Userid:   s.CreatorID,
+			Username: GetUserName(s.CreatorID),
+		},
+	}
+}
+
+func (r *Room) broadcastSchedulesChanged(sender *pb.Sender) error {
+	return r.Broadcast(&pb.ElementMessage{
+		Type:             pb.ElementMessageType_SCHEDULES_CHANGED,
+		SchedulesChanged: sender,
+	})
+}
+
+// Schedules returns the schedules of the room that have not started, ordered by start time
+func (r *Room) Schedules() ([]*model.RoomSchedule, error) {
+	return db.GetRoomSchedules(r.ID)
+}
+
+func (r *Room) AddSchedule(s *model.RoomSchedule) error {
+	if !s.StartAt.After(time.Now()) {
+		return ErrScheduleInPast
+	}
+	if _, err := r.GetMovieByID(s.MovieID); err != nil {
+		return err
+	}
+	s.RoomID = r.ID
+	return db.CreateRoomSchedule(s)
+}
+
+func (r *Room) CancelSchedule(id string) error {
+	if err := db.DeleteRoomSchedule(r.ID, id); err != nil {
+		return err
+	}
+	if t, ok := armedSchedules.LoadAndDelete(id); ok && t != nil {
+		t.Stop()
+	}
+	return nil
+}
+
+func (u *User) ScheduleRoomMovie(room *Room, movieID, title string, startAt time.Time) (*model.RoomSchedule, error) {
+	if !u.HasRoomAdminPermission(room, model.PermissionScheduleMovie) {
+		return nil, model.ErrNoPermission
+	}
+	s := &model.RoomSchedule{
+		MovieID:   movieID,
+		CreatorID: u.ID,
+		Title:     title,
+		StartAt:   startAt,
+	}
+	if err := room.AddSchedule(s); err != nil {
+		return nil, err
+	}
+	return s, room.broadcastSchedulesChanged(&pb.Sender{
+		Username: u.Username,
+		Userid:   u.ID,
+	})
+}
+
+func (u *User) CancelRoomSchedule(room *Room, id string) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionScheduleMovie) {
+		return model.ErrNoPermission
+	}
+	if err := room.CancelSchedule(id); err != nil {
+		return err
+	}
+	return room.broadcastSchedulesChanged(&pb.Sender{
+		Username: u.Username,
+		Userid:   u.ID,
+	})
+}
@@ -0,0 +1,223 @@
+package op
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+	pb "github.com/synctv-org/synctv/proto/message"
+)
+
+// SlowConsumerPolicy decides what to do with a message
+// when the channel of the client is full.
+type SlowConsumerPolicy string
+
+const (
+	// drop the oldest queued message to make room
+	SlowConsumerDropOldest SlowConsumerPolicy = "drop_oldest"
+	// drop the queued messages superseded by newer ones, such as old movie status,
+	// and the oldest ones if that is not enough
+	SlowConsumerCoalesce SlowConsumerPolicy = "coalesce"
+	// drop the new message and disconnect the client after too many drops
+	SlowConsumerDisconnect SlowConsumerPolicy = "disconnect"
+)
+
+var (
+	slowConsumerPolicy            = SlowConsumerDropOldest
+	slowConsumerMaxDropped uint64 = 64
+
+	droppedMessages atomic.Uint64
+	evictedClients  atomic.Uint64
+)
+
+func ParseSlowConsumerPolicy(policy string) (SlowConsumerPolicy, error) {
+	switch p := SlowConsumerPolicy(policy); p {
+	case SlowConsumerDropOldest, SlowConsumerCoalesce, SlowConsumerDisconnect:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown slow consumer policy: %s", policy)
+	}
+}
+
+// SetSlowConsumerPolicy sets the default policy of the clients
+// that do not ask for their own.
+func SetSlowConsumerPolicy(policy SlowConsumerPolicy, maxDropped uint64) error {
+	if policy == "" {
+		policy = SlowConsumerDropOldest
+	}
+	policy, err := ParseSlowConsumerPolicy(string(policy))
+	if err != nil {
+		return err
+	}
+	if maxDropped == 0 {
+		maxDropped = 64
+	}
+	slowConsumerPolicy = policy
+	slowConsumerMaxDropped = maxDropped
+	return nil
+}
+
+// WithSlowConsumerPolicy overrides the default slow consumer policy for the client,
+// a zero maxDropped keeps the default one.
+func WithSlowConsumerPolicy(policy SlowConsumerPolicy, maxDropped uint64) ClientConf {
+	return func(c *Client) {
+		c.policy = policy
+		if maxDropped != 0 {
+			c.maxDropped = maxDropped
+		}
+	}
+}
+
+// SlowConsumerStats returns the number of messages dropped
+// and clients disconnected for being too slow since the start.
+func SlowConsumerStats() (dropped, evicted uint64) {
+	return droppedMessages.Load(), evictedClients.Load()
+}
+
+// deliver sends the message without blocking the sender,
+// a full channel is handled by the slow consumer policy of the client.
+func (c *Client) deliver(msg Message) error {
+	c.wg.Add(1)
+	defer c.wg.Done()
+	if c.Closed() {
+		return ErrAlreadyClosed
+	}
+	msg = c.encode(msg)
+	select {
+	case c.c <- msg:
+		return nil
+	default:
+	}
+	switch c.policy {
+	case SlowConsumerDisconnect:
+		c.drop()
+		if c.dropped.Load() >= c.maxDropped {
+			c.evict()
+		}
+	case SlowConsumerCoalesce:
+		c.coalesce(msg)
+	default:
+		c.dropOldest(msg)
+	}
+	return nil
+}
+
+func (c *Client) drop() {
+	c.dropped.Add(1)
+	droppedMessages.Add(1)
+}
+
+// Dropped returns the number of messages dropped for the client
+func (c *Client) Dropped() uint64 {
+	return c.dropped.Load()
+}
+
+func (c *Client) evict() {
+	if !c.evicted.CompareAndSwap(false, true) {
+		return
+	}
+	evictedClients.Add(1)
+	log.Warnf("client: %s, room: %s, too slow, disconnected after dropping %d messages", c.u.ID, c.r.ID, c.dropped.Load())
+	if c.ReadOnly() {
+		// a read-only client, its handler stops when the read chan is closed
+		go c.Close()
+		return
+	}
+	// the writer fails and the handler closes the client
+	go c.conn.Close()
+}
+
+func (c *Client) dropOldest(msg Message) {
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+	for {
+		select {
+		case c.c <- msg:
+			return
+		default:
+		}
+		select {
+		case <-c.c:
+			c.drop()
+		default:
+		}
+	}
+}
+
+func (c *Client) coalesce(msg Message) {
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+	pending := make([]Message, 0, cap(c.c)+1)
+	for drained := false; !drained; {
+		select {
+		case m := <-c.c:
+			pending = append(pending, m)
+		default:
+			drained = true
+		}
+	}
+	pending = append(pending, msg)
+
+	latest := make(map[string]int)
+	for i, m := range pending {
+		if key, ok := coalesceKey(m); ok {
+			latest[key] = i
+		}
+	}
+	kept := pending[:0]
+	for i, m := range pending {
+		if key, ok := coalesceKey(m); ok && latest[key] != i {
+			c.drop()
+			continue
+		}
+		kept = append(kept, m)
+	}
+	if over := len(kept) - cap(c.c); over > 0 {
+		for range kept[:over] {
+			c.drop()
+		}
+		kept = kept[over:]
+	}
+	for _, m := range kept {
+		select {
+		case c.c <- m:
+		default:
+			// filled by a concurrent Send meanwhile
+			c.drop()
+		}
+	}
+}
+
+// coalesceKey returns the key of the messages that only the latest one matters,
+// ok is false if every message of its kind must be delivered.
+func coalesceKey(msg Message) (key string, ok bool) {
+	var em *pb.ElementMessage
+	switch m := msg.(type) {
+	case *PingMessage:
+		return "ping", true
+	case *pb.ElementMessage:
+		em = m
+	case *pb.JSONElementMessage:
+		em = m.ElementMessage
+	default:
+		return "", false
+	}
+	switch em.Type {
+	case pb.ElementMessageType_PLAY,
+		pb.ElementMessageType_PAUSE,
+		pb.ElementMessageType_CHANGE_RATE,
+		pb.ElementMessageType_CHANGE_SEEK:
+		// all of them carry the full movie status
+		return "status", true
+	case pb.ElementMessageType_PEOPLE_CHANGED,
+		pb.ElementMessageType_CURRENT_CHANGED,
+		pb.ElementMessageType_MOVIES_CHANGED,
+		pb.ElementMessageType_POLL_UPDATED,
+		pb.ElementMessageType_SCHEDULES_CHANGED:
+		return em.Type.String(), true
+	case pb.ElementMessageType_PRESENCE_UPDATE:
+		return em.Type.String() + ":" + em.GetPresence().GetUser().GetUserid(), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,214 @@
+package op
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/synctv-org/synctv/internal/broadcaster"
+	"github.com/synctv-org/synctv/internal/conf"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	pb "github.com/synctv-org/synctv/proto/message"
+	"github.com/zijiren233/gencontainer/synccache"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// memBroadcaster delivers the events to every subscriber of the process right away
+type memBroadcaster struct {
+	lock sync.Mutex
+	subs map[string][]broadcaster.Handler
+}
+
+func (m *memBroadcaster) NodeID() string {
+	return "test"
+}
+
+func (m *memBroadcaster) Publish(ctx context.Context, roomID string, e *broadcaster.Event) error {
+	m.lock.Lock()
+	subs := append([]broadcaster.Handler(nil), m.subs[roomID]...)
+	m.lock.Unlock()
+	for _, h := range subs {
+		h(e)
+	}
+	return nil
+}
+
+func (m *memBroadcaster) Subscribe(roomID string, handler broadcaster.Handler) (func(), error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.subs[roomID] = append(m.subs[roomID], handler)
+	return func() {}, nil
+}
+
+func (m *memBroadcaster) Close() error {
+	return nil
+}
+
+// newTestNodes returns the same room as loaded by two nodes, a changes the room and b follows,
+// b is cached and has a client so it is subscribed to both the control channel and the room.
+func newTestNodes(t *testing.T) (a, b *Room, creator *model.User) {
+	t.Helper()
+	conf.Conf = conf.DefaultConfig()
+	d, err := gorm.Open(sqlite.Open("file::memory:?_pragma=foreign_keys(1)"), &gorm.Config{
+		TranslateError: true,
+		Logger:         logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := d.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every connection to an in-memory database is a new database
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.Init(d, conf.DatabaseTypeSqlite3); err != nil {
+		t.Fatal(err)
+	}
+	SetBroadcaster(&memBroadcaster{subs: map[string][]broadcaster.Handler{}})
+	roomCache = synccache.NewSyncCache[string, *Room](time.Minute)
+	userCache = synccache.NewSyncCache[string, *User](time.Minute)
+	subscribeControl()
+
+	creator, err = db.CreateUser("creator", "password", db.WithRole(model.RoleUser))
+	if err != nil {
+		t.Fatal(err)
+	}
+	room, err := db.CreateRoom("room", "", 0, db.WithCreator(creator), db.WithStatus(model.RoomStatusActive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := LoadOrInitRoomByID(room.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b = e.Value()
+	if _, err := b.NewClient(&User{User: *creator}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := db.GetOrCreateRoomSettings(room.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	room.Settings = settings
+	a = &Room{
+		Room:    *room,
+		current: loadCurrent(room.ID),
+		movies: movies{
+			roomID: room.ID,
+		},
+	}
+	t.Cleanup(func() {
+		a.close()
+		b.close()
+		roomCache.Clear()
+		db.Close()
+		SetBroadcaster(broadcaster.NewLocal())
+	})
+	return a, b, creator
+}
+
+func TestRoomFollowsOtherNode(t *testing.T) {
+	a, b, creator := newTestNodes(t)
+
+	movie := &model.Movie{
+		CreatorID: creator.ID,
+		Base: model.BaseMovie{
+			Url:  "https://example.com/movie.mp4",
+			Name: "movie",
+		},
+	}
+	if err := a.AddMovie(movie); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.GetMovieByID(movie.ID); err != nil {
+		t.Fatalf("movie added on the other node: %v", err)
+	}
+
+	if err := a.SetCurrentMovie(movie.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	if c := b.Current(); c.MovieID != movie.ID || !c.Status.Playing {
+		t.Fatalf("current movie = %s, playing %v, want %s playing", c.MovieID, c.Status.Playing, movie.ID)
+	}
+	a.SetCurrentStatus(false, 42, 1, 0)
+	if s := b.Current().Status; s.Playing || s.Seek != 42 {
+		t.Fatalf("status = playing %v at %v, want paused at 42", s.Playing, s.Seek)
+	}
+	if _, dirty := b.current.takeDirty(); dirty {
+		t.Fatal("the followed status must be persisted by the node that changed it")
+	}
+
+	if err := a.UpdateSettings(map[string]any{"can_send_chat_message": false}); err != nil {
+		t.Fatal(err)
+	}
+	if b.Settings.CanSendChatMessage {
+		t.Fatal("settings changed on the other node are not reloaded")
+	}
+
+	member, err := db.CreateUser("member", "password", db.WithRole(model.RoleUser))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.LoadOrCreateRoomMember(member.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.MuteMember(member.ID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	m, err := b.LoadRoomMember(member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.IsMuted() {
+		t.Fatal("member muted on the other node is not reloaded")
+	}
+}
+
+func TestRoomCountsBufferingOnOtherNode(t *testing.T) {
+	_, b, _ := newTestNodes(t)
+
+	presence := func(typ pb.ElementMessageType, buffering bool) {
+		t.Helper()
+		data, err := proto.Marshal(&pb.ElementMessage{
+			Type: typ,
+			Presence: &pb.Presence{
+				User:      &pb.Sender{Userid: "member", Username: "member"},
+				Devices:   1,
+				Buffering: buffering,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.hub.handleRemoteEvent(&broadcaster.Event{
+			Node: "other",
+			Type: broadcaster.EventBroadcast,
+			Data: data,
+		})
+	}
+
+	presence(pb.ElementMessageType_PRESENCE_JOIN, false)
+	if b.bufferingReached() {
+		t.Fatal("nobody is buffering yet")
+	}
+	presence(pb.ElementMessageType_PRESENCE_UPDATE, true)
+	if !b.bufferingReached() {
+		t.Fatal("member buffering on the other node is not counted")
+	}
+	presence(pb.ElementMessageType_PRESENCE_UPDATE, false)
+	if b.bufferingReached() {
+		t.Fatal("member done buffering on the other node is still counted")
+	}
+	presence(pb.ElementMessageType_PRESENCE_UPDATE, true)
+	presence(pb.ElementMessageType_PRESENCE_LEAVE, false)
+	if b.bufferingReached() {
+		t.Fatal("member who left is still counted")
+	}
+}
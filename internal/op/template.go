@@ -0,0 +1,105 @@
+package op
+
+import (
+	"fmt"
+
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+)
+
+func (u *User) RoomTemplates() ([]*model.RoomTemplate, error) {
+	return db.GetRoomTemplates(u.ID)
+}
+
+func (u *User) RoomTemplate(id string) (*model.RoomTemplate, error) {
+	return db.GetRoomTemplate(u.ID, id)
+}
+
+func (u *User) CreateRoomTemplate(name string, settings *model.RoomSettings, permissions model.RoomMemberPermission, movies []*model.BaseMovie) (*model.RoomTemplate, error) {
+	if settings == nil {
+		settings = model.DefaultRoomSettings()
+	}
+	// the rooms created from the template take the settings as they are
+	if err := validateSettings(settings); err != nil {
+		return nil, err
+	}
+	if err := validateBaseMovies(movies); err != nil {
+		return nil, err
+	}
+	t := &model.RoomTemplate{
+		CreatorID:   u.ID,
+		Name:        name,
+		Settings:    settings,
+		Permissions: permissions,
+		Movies:      make([]*model.RoomTemplateMovie, len(movies)),
+	}
+	for i, m := range movies {
+		t.Movies[i] = &model.RoomTemplateMovie{
+			Position: uint(i),
+			Base:     *m,
+		}
+	}
+	return t, db.CreateRoomTemplate(t)
+}
+
+// SaveRoomAsTemplate saves the settings of the room, and the playlist if withMovies, as a template of the user
+func (u *User) SaveRoomAsTemplate(room *Room, name string, withMovies bool) (*model.RoomTemplate, error) {
+	if !u.HasRoomAdminPermission(room, model.PermissionExportRoom) {
+		return nil, model.ErrNoPermission
+	}
+	settings, err := db.GetOrCreateRoomSettings(room.ID)
+	if err != nil {
+		return nil, err
+	}
+	var movies []*model.BaseMovie
+	if withMovies {
+		for _, m := range db.GetAllMoviesByRoomID(room.ID) {
+			movies = append(movies, &m.Base)
+		}
+	}
+	return u.CreateRoomTemplate(name, settings, settings.UserDefaultPermissions, movies)
+}
+
+func (u *User) DeleteRoomTemplate(id string) error {
+	return db.DeleteRoomTemplate(u.ID, id)
+}
+
+// CreateRoomFromTemplate is CreateRoom with the settings and movies of the template
+func (u *User) CreateRoomFromTemplate(templateID, name, password string, conf ...db.CreateRoomConfig) (*RoomEntry, error) {
+	t, err := u.RoomTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	// the settings of the site may have changed since the template was saved
+	for _, m := range t.Movies {
+		if err := validateBaseMovie(&m.Base); err != nil {
+			return nil, fmt.Errorf("movie %s: %w", m.Base.Name, err)
+		}
+	}
+	c, err := db.WithTemplate(t, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	return u.CreateRoom(name, password, append([]db.CreateRoomConfig{c}, conf...)...)
+}
+
+// CloneRoom creates a room owned by the user with the settings and playlist of the room
+func (u *User) CloneRoom(room *Room, name, password string) (*RoomEntry, error) {
+	if !u.HasRoomAdminPermission(room, model.PermissionExportRoom) {
+		return nil, model.ErrNoPermission
+	}
+	conf, err := db.WithClone(room.ID, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	return u.CreateRoom(name, password, conf)
+}
+
+func validateBaseMovies(movies []*model.BaseMovie) error {
+	for _, m := range movies {
+		if err := validateBaseMovie(m); err != nil {
+			return fmt.Errorf("movie %s: %w", m.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package op
+
+import (
+	"errors"
+	"time"
+
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/settings"
+)
+
+const roomTransferTTL = 7 * 24 * time.Hour
+
+var ErrRoomTransferExpired = errors.New("transfer offer has expired")
+
+// maxRoomCount is the number of rooms the user may own, 0 means no limit
+func (u *User) maxRoomCount() int64 {
+	if u.IsAdmin() {
+		return 0
+	}
+	return settings.UserMaxRoomCount.Get()
+}
+
+// OfferRoomTransfer offers the room to an active member, it replaces the pending offer
+func (u *User) OfferRoomTransfer(room *Room, userID string) (*model.RoomTransfer, error) {
+	if !u.IsRoomCreator(room) {
+		return nil, model.ErrNoPermission
+	}
+	if userID == u.ID {
+		return nil, errors.New("cannot transfer the room to yourself")
+	}
+	if room.IsGuest(userID) {
+		return nil, errors.New("cannot transfer the room to guest")
+	}
+	member, err := room.LoadRoomMember(userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Status.IsNotActive() {
+		return nil, errors.New("the new owner is not an active member")
+	}
+	t := &model.RoomTransfer{
+		RoomID:    room.ID,
+		FromID:    u.ID,
+		ToID:      userID,
+		ExpiresAt: time.Now().Add(roomTransferTTL),
+	}
+	return t, db.SaveRoomTransfer(t)
+}
+
+func (u *User) RoomTransfer(room *Room) (*model.RoomTransfer, error) {
+	if !u.IsRoomCreator(room) {
+		return nil, model.ErrNoPermission
+	}
+	return db.GetRoomTransfer(room.ID)
+}
+
+func (u *User) CancelRoomTransfer(room *Room) error {
+	if !u.IsRoomCreator(room) {
+		return model.ErrNoPermission
+	}
+	return db.DeleteRoomTransfer(room.ID)
+}
+
+// IncomingRoomTransfers returns the pending offers to the user
+func (u *User) IncomingRoomTransfers() ([]*model.RoomTransfer, error) {
+	return db.GetRoomTransfersTo(u.ID)
+}
+
+func (u *User) incomingRoomTransfer(roomID string) (*model.RoomTransfer, error) {
+	t, err := db.GetRoomTransfer(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if t.ToID != u.ID {
+		return nil, db.ErrNotFound("transfer")
+	}
+	return t, nil
+}
+
+func (u *User) AcceptRoomTransfer(roomID string) error {
+	t, err := u.incomingRoomTransfer(roomID)
+	if err != nil {
+		return err
+	}
+	if !time.Now().Before(t.ExpiresAt) {
+		_ = db.DeleteRoomTransfer(roomID)
+		return ErrRoomTransferExpired
+	}
+	err = transferRoom(u, roomID, t.FromID, u)
+	if errors.Is(err, db.ErrRoomCreatorChanged) {
+		_ = db.DeleteRoomTransfer(roomID)
+	}
+	return err
+}
+
+func (u *User) DeclineRoomTransfer(roomID string) error {
+	if _, err := u.incomingRoomTransfer(roomID); err != nil {
+		return err
+	}
+	return db.DeleteRoomTransfer(roomID)
+}
+
+// TransferRoomByID is the server admin override handing the room over to a member without an offer,
+// the room may not be loadable, e.g. when its creator is banned.
+func (u *User) TransferRoomByID(roomID, userID string) error {
+	if !u.IsAdmin() {
+		return model.ErrNoPermission
+	}
+	r, err := db.GetRoomByID(roomID)
+	if err != nil {
+		return err
+	}
+	if r.CreatorID == userID {
+		return errors.New("the user is already the owner")
+	}
+	if creator, err := LoadOrInitUserByID(r.CreatorID); err == nil {
+		if creator.Value().IsRoot() {
+			return errors.New("cannot transfer root room")
+		}
+		if creator.Value().IsAdmin() && !u.IsRoot() {
+			return model.ErrNoPermission
+		}
+	}
+	to, err := LoadOrInitUserByID(userID)
+	if err != nil {
+		return err
+	}
+	return transferRoom(u, roomID, r.CreatorID, to.Value())
+}
+
+// transferRoom makes the member the creator of the room, the room tokens issued before are invalid afterwards
+func transferRoom(actor *User, roomID, fromID string, to *User) error {
+	if to.IsGuest() {
+		return errors.New("cannot transfer the room to guest")
+	}
+	if err := db.TransferRoom(roomID, fromID, to.ID, to.maxRoomCount()); err != nil {
+		return err
+	}
+	// the creator is part of the cached room and its tokens, every node reloads it
+	evictRoom(roomID)
+	auditRoom(roomID, actor, model.AuditActionOwnerTransfer, to.ID, &auditOwner{CreatorID: fromID}, &auditOwner{CreatorID: to.ID})
+	return nil
+}
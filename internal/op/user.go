@@ -139,6 +139,10 @@ func (u *User) AddRoomMovie(room *Room, movie *model.BaseMovie) error {
 	if err != nil {
 		return err
 	}
+	room.emitWebhook(model.WebhookEventMovieAdded, WebhookData{
+		Sender: webhookUser(u),
+		Movies: []*WebhookMovie{webhookMovie(m)},
+	})
 	return room.Broadcast(&pb.ElementMessage{
 		Type: pb.ElementMessageType_MOVIES_CHANGED,
 		MoviesChanged: &pb.Sender{
@@ -172,6 +176,14 @@ func (u *User) AddRoomMovies(room *Room, movies []*model.BaseMovie) error {
 	if err != nil {
 		return err
 	}
+	data := WebhookData{
+		Sender: webhookUser(u),
+		Movies: make([]*WebhookMovie, len(m)),
+	}
+	for i, mm := range m {
+		data.Movies[i] = webhookMovie(mm)
+	}
+	room.emitWebhook(model.WebhookEventMovieAdded, data)
 	return room.Broadcast(&pb.ElementMessage{
 		Type: pb.ElementMessageType_MOVIES_CHANGED,
 		MoviesChanged: &pb.Sender{
@@ -245,7 +257,12 @@ func (u *User) SetRoomPassword(room *Room, password string) error {
 			return errors.New("room must no need password")
 		}
 	}
-	return room.SetPassword(password)
+	old := &auditPassword{HasPassword: room.NeedPassword()}
+	if err := room.SetPassword(password); err != nil {
+		return err
+	}
+	room.audit(u, model.AuditActionPassword, "", old, &auditPassword{HasPassword: room.NeedPassword()})
+	return nil
 }
 
 func (u *User) SetUserRole() error {
@@ -329,14 +346,33 @@ func (u *User) SetRoomSettings(room *Room, setting *model.RoomSettings) error {
 	if !u.HasRoomAdminPermission(room, model.PermissionSetRoomSettings) {
 		return model.ErrNoPermission
 	}
-	return room.SetSettings(setting)
+	old := room.Settings
+	if setting.ChatSlowModeInterval != old.ChatSlowModeInterval ||
+		setting.ChatBlocklist != old.ChatBlocklist {
+		return ErrChatModerationSettings
+	}
+	if err := room.SetSettings(setting); err != nil {
+		return err
+	}
+	room.audit(u, model.AuditActionSettings, "", old, setting)
+	return nil
 }
 
 func (u *User) UpdateRoomSettings(room *Room, settings map[string]interface{}) error {
 	if !u.HasRoomAdminPermission(room, model.PermissionSetRoomSettings) {
 		return model.ErrNoPermission
 	}
-	return room.UpdateSettings(settings)
+	for _, k := range chatModerationSettings {
+		if _, ok := settings[k]; ok {
+			return ErrChatModerationSettings
+		}
+	}
+	old := auditSettings(room.Settings, settings)
+	if err := room.UpdateSettings(settings); err != nil {
+		return err
+	}
+	room.audit(u, model.AuditActionSettings, "", old, settings)
+	return nil
 }
 
 func (u *User) DeleteRoomMovieByID(room *Room, movieID string) error {
@@ -347,11 +383,24 @@ func (u *User) DeleteRoomMovieByID(room *Room, movieID string) error {
 	if m.Movie.CreatorID != u.ID && !u.HasRoomPermission(room, model.PermissionDeleteMovie) {
 		return model.ErrNoPermission
 	}
-	return room.DeleteMovieByID(movieID)
+	if err := room.DeleteMovieByID(movieID); err != nil {
+		return err
+	}
+	room.audit(u, model.AuditActionMovieDelete, movieID, newAuditMovie(m.Movie), nil)
+	room.emitWebhook(model.WebhookEventMovieDeleted, WebhookData{
+		Sender: webhookUser(u),
+		Movies: []*WebhookMovie{webhookMovie(m.Movie)},
+	})
+	return nil
 }
 
 func (u *User) DeleteRoomMoviesByID(room *Room, movieIDs []string) error {
-	for _, id := range movieIDs {
+	data := WebhookData{
+		Sender: webhookUser(u),
+		Movies: make([]*WebhookMovie, len(movieIDs)),
+	}
+	audits := make([]*auditMovie, len(movieIDs))
+	for i, id := range movieIDs {
 		m, err := room.GetMovieByID(id)
 		if err != nil {
 			return err
@@ -359,10 +408,16 @@ func (u *User) DeleteRoomMoviesByID(room *Room, movieIDs []string) error {
 		if m.Movie.CreatorID != u.ID && !u.HasRoomPermission(room, model.PermissionDeleteMovie) {
 			return model.ErrNoPermission
 		}
+		data.Movies[i] = webhookMovie(m.Movie)
+		audits[i] = newAuditMovie(m.Movie)
 	}
 	if err := room.DeleteMoviesByID(movieIDs); err != nil {
 		return err
 	}
+	for _, m := range audits {
+		room.audit(u, model.AuditActionMovieDelete, m.ID, m, nil)
+	}
+	room.emitWebhook(model.WebhookEventMovieDeleted, data)
 	return room.Broadcast(&pb.ElementMessage{
 		Type: pb.ElementMessageType_MOVIES_CHANGED,
 		MoviesChanged: &pb.Sender{
@@ -376,10 +431,24 @@ func (u *User) ClearRoomMovies(room *Room) error {
 	if !u.HasRoomPermission(room, model.PermissionDeleteMovie) {
 		return model.ErrNoPermission
 	}
+	ms, _ := room.GetMoviesWithPage(1, room.movies.Len(), "")
 	err := room.ClearMovies()
 	if err != nil {
 		return err
 	}
+	if len(ms) != 0 {
+		data := WebhookData{
+			Sender: webhookUser(u),
+			Movies: make([]*WebhookMovie, len(ms)),
+		}
+		audits := make([]*auditMovie, len(ms))
+		for i, m := range ms {
+			data.Movies[i] = webhookMovie(m.Movie)
+			audits[i] = newAuditMovie(m.Movie)
+		}
+		room.emitWebhook(model.WebhookEventMovieDeleted, data)
+		room.audit(u, model.AuditActionMovieClear, "", audits, nil)
+	}
 	return room.Broadcast(&pb.ElementMessage{
 		Type: pb.ElementMessageType_MOVIES_CHANGED,
 		MoviesChanged: &pb.Sender{
@@ -410,16 +479,9 @@ func (u *User) SetRoomCurrentMovie(room *Room, movieID string, play bool) error
 	if !u.HasRoomPermission(room, model.PermissionSetCurrentMovie) {
 		return model.ErrNoPermission
 	}
-	err := room.SetCurrentMovie(movieID, play)
-	if err != nil {
-		return err
-	}
-	return room.Broadcast(&pb.ElementMessage{
-		Type: pb.ElementMessageType_CURRENT_CHANGED,
-		CurrentChanged: &pb.Sender{
-			Username: u.Username,
-			Userid:   u.ID,
-		},
+	return room.changeCurrentMovie(movieID, play, &pb.Sender{
+		Username: u.Username,
+		Userid:   u.ID,
 	})
 }
 
@@ -496,6 +558,9 @@ func (u *User) SetRoomCurrentSeekRate(room *Room, seek, rate, timeDiff float64)
 	if !u.HasRoomPermission(room, model.PermissionSetCurrentStatus) {
 		return nil, model.ErrNoPermission
 	}
+	if err := room.checkHostControl(u.ID); err != nil {
+		return nil, err
+	}
 	return room.SetCurrentSeekRate(seek, rate, timeDiff), nil
 }
 
@@ -503,6 +568,9 @@ func (u *User) SetRoomCurrentStatus(room *Room, playing bool, seek, rate, timeDi
 	if !u.HasRoomPermission(room, model.PermissionSetCurrentStatus) {
 		return nil, model.ErrNoPermission
 	}
+	if err := room.checkHostControl(u.ID); err != nil {
+		return nil, err
+	}
 	return room.SetCurrentStatus(playing, seek, rate, timeDiff), nil
 }
 
@@ -516,7 +584,17 @@ func (u *User) BanRoomMember(room *Room, userID string) error {
 	if room.IsAdmin(userID) && !u.IsRoomCreator(room) {
 		return errors.New("cannot ban admin")
 	}
-	return room.BanMember(userID)
+	err := room.auditMemberChange(u, model.AuditActionMemberBan, userID, func() error {
+		return room.BanMember(userID)
+	})
+	if err != nil {
+		return err
+	}
+	room.emitWebhook(model.WebhookEventMemberBanned, WebhookData{
+		Sender: webhookUser(u),
+		Member: webhookUserByID(userID),
+	})
+	return nil
 }
 
 func (u *User) UnbanRoomMember(room *Room, userID string) error {
@@ -526,7 +604,9 @@ func (u *User) UnbanRoomMember(room *Room, userID string) error {
 	if u.ID == userID {
 		return errors.New("cannot unban yourself")
 	}
-	return room.UnbanMember(userID)
+	return room.auditMemberChange(u, model.AuditActionMemberUnban, userID, func() error {
+		return room.UnbanMember(userID)
+	})
 }
 
 func (u *User) SetMemberPermissions(room *Room, userID string, permissions model.RoomMemberPermission) error {
@@ -536,7 +616,9 @@ func (u *User) SetMemberPermissions(room *Room, userID string, permissions model
 	if room.IsAdmin(userID) && !u.IsRoomCreator(room) {
 		return errors.New("cannot set admin permissions")
 	}
-	return room.SetMemberPermissions(userID, permissions)
+	return room.auditMemberChange(u, model.AuditActionMemberPermissions, userID, func() error {
+		return room.SetMemberPermissions(userID, permissions)
+	})
 }
 
 func (u *User) AddMemberPermissions(room *Room, userID string, permissions model.RoomMemberPermission) error {
@@ -546,7 +628,9 @@ func (u *User) AddMemberPermissions(room *Room, userID string, permissions model
 	if room.IsAdmin(userID) && !u.IsRoomCreator(room) {
 		return errors.New("cannot add admin permissions")
 	}
-	return room.AddMemberPermissions(userID, permissions)
+	return room.auditMemberChange(u, model.AuditActionMemberPermissions, userID, func() error {
+		return room.AddMemberPermissions(userID, permissions)
+	})
 }
 
 func (u *User) RemoveMemberPermissions(room *Room, userID string, permissions model.RoomMemberPermission) error {
@@ -556,7 +640,9 @@ func (u *User) RemoveMemberPermissions(room *Room, userID string, permissions mo
 	if room.IsAdmin(userID) && !u.IsRoomCreator(room) {
 		return errors.New("cannot remove admin permissions")
 	}
-	return room.RemoveMemberPermissions(userID, permissions)
+	return room.auditMemberChange(u, model.AuditActionMemberPermissions, userID, func() error {
+		return room.RemoveMemberPermissions(userID, permissions)
+	})
 }
 
 func (u *User) ResetMemberPermissions(room *Room, userID string) error {
@@ -566,54 +652,75 @@ func (u *User) ResetMemberPermissions(room *Room, userID string) error {
 	if room.IsAdmin(userID) && !u.IsRoomCreator(room) {
 		return errors.New("cannot reset admin permissions")
 	}
-	return room.ResetMemberPermissions(userID)
+	return room.auditMemberChange(u, model.AuditActionMemberPermissions, userID, func() error {
+		return room.ResetMemberPermissions(userID)
+	})
 }
 
 func (u *User) ApproveRoomPendingMember(room *Room, userID string) error {
 	if !u.HasRoomAdminPermission(room, model.PermissionApprovePendingMember) {
 		return model.ErrNoPermission
 	}
-	return room.ApprovePendingMember(userID)
+	if err := room.ApprovePendingMember(userID); err != nil {
+		return err
+	}
+	room.emitWebhook(model.WebhookEventMemberApproved, WebhookData{
+		Sender: webhookUser(u),
+		Member: webhookUserByID(userID),
+	})
+	return nil
 }
 
 func (u *User) SetRoomAdmin(room *Room, userID string, permissions model.RoomAdminPermission) error {
 	if !u.IsRoomCreator(room) {
 		return model.ErrNoPermission
 	}
-	return room.SetAdmin(userID, permissions)
+	return room.auditMemberChange(u, model.AuditActionMemberRole, userID, func() error {
+		return room.SetAdmin(userID, permissions)
+	})
 }
 
 func (u *User) SetRoomMember(room *Room, userID string, permissions model.RoomMemberPermission) error {
 	if !u.IsRoomCreator(room) {
 		return model.ErrNoPermission
 	}
-	return room.SetMember(userID, permissions)
+	return room.auditMemberChange(u, model.AuditActionMemberRole, userID, func() error {
+		return room.SetMember(userID, permissions)
+	})
 }
 
 func (u *User) SetRoomAdminPermissions(room *Room, userID string, permissions model.RoomAdminPermission) error {
 	if !u.IsRoomCreator(room) {
 		return model.ErrNoPermission
 	}
-	return room.SetAdminPermissions(userID, permissions)
+	return room.auditMemberChange(u, model.AuditActionMemberAdminPermissions, userID, func() error {
+		return room.SetAdminPermissions(userID, permissions)
+	})
 }
 
 func (u *User) AddRoomAdminPermissions(room *Room, userID string, permissions model.RoomAdminPermission) error {
 	if !u.IsRoomCreator(room) {
 		return model.ErrNoPermission
 	}
-	return room.AddAdminPermissions(userID, permissions)
+	return room.auditMemberChange(u, model.AuditActionMemberAdminPermissions, userID, func() error {
+		return room.AddAdminPermissions(userID, permissions)
+	})
 }
 
 func (u *User) RemoveRoomAdminPermissions(room *Room, userID string, permissions model.RoomAdminPermission) error {
 	if !u.IsRoomCreator(room) {
 		return model.ErrNoPermission
 	}
-	return room.RemoveAdminPermissions(userID, permissions)
+	return room.auditMemberChange(u, model.AuditActionMemberAdminPermissions, userID, func() error {
+		return room.RemoveAdminPermissions(userID, permissions)
+	})
 }
 
 func (u *User) ResetRoomAdminPermissions(room *Room, userID string) error {
 	if !u.IsRoomCreator(room) {
 		return model.ErrNoPermission
 	}
-	return room.ResetAdminPermissions(userID)
+	return room.auditMemberChange(u, model.AuditActionMemberAdminPermissions, userID, func() error {
+		return room.ResetAdminPermissions(userID)
+	})
 }
@@ -0,0 +1,424 @@
+package op
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	json "github.com/json-iterator/go"
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/settings"
+	pb "github.com/synctv-org/synctv/proto/message"
+	"github.com/synctv-org/synctv/utils"
+)
+
+const (
+	webhookTimeout     = 10 * time.Second
+	webhookMaxAttempts = 6
+	// deliveries sent per second at most
+	webhookBatchSize = 32
+	// the finished deliveries are kept in the log for this long
+	webhookDeliveryRetention = 7 * 24 * time.Hour
+	// the webhooks may be changed on another node, so the cached ones expire
+	webhookCacheTTL = time.Minute
+	// the events waiting to be queued for delivery, more are dropped
+	webhookEventQueueSize = 1024
+)
+
+var (
+	ErrInvalidWebhookURL   = errors.New("webhook url must be an absolute http or https url")
+	ErrLocalWebhookURL     = errors.New("webhook url must not point to a local address")
+	ErrUnknownWebhookEvent = errors.New("unknown webhook event")
+)
+
+var webhookClient = &http.Client{
+	Timeout: webhookTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: webhookTimeout,
+			Control: webhookDialControl,
+		}).DialContext,
+		TLSHandshakeTimeout: webhookTimeout,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// webhookDialControl refuses to connect to a local address unless proxying to local is allowed,
+// the resolved address is checked so a dns name rebound after the url was saved can not reach one either.
+func webhookDialControl(network, address string, _ syscall.RawConn) error {
+	if settings.AllowProxyToLocal.Get() {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsUnspecified() ||
+		utils.IsLocalIP(host) {
+		return fmt.Errorf("%w: %s", ErrLocalWebhookURL, host)
+	}
+	return nil
+}
+
+// the webhook events are turned into deliveries in the background,
+// so the broadcasts never wait for the database
+var webhookEvents = make(chan func(), webhookEventQueueSize)
+
+func queueWebhookEvent(f func()) {
+	select {
+	case webhookEvents <- f:
+	default:
+		log.Warn("webhook event queue is full, event dropped")
+	}
+}
+
+func runWebhookEvents() {
+	for f := range webhookEvents {
+		f()
+	}
+}
+
+type cachedWebhooks struct {
+	list     []*model.RoomWebhook
+	loadedAt time.Time
+}
+
+type WebhookPayload struct {
+	Event  model.WebhookEvent `json:"event"`
+	RoomID string             `json:"roomId"`
+	// unix milliseconds
+	Time int64       `json:"time"`
+	Data WebhookData `json:"data"`
+}
+
+type WebhookData struct {
+	// who caused the event
+	Sender *WebhookUser `json:"sender,omitempty"`
+	// the member who joined, was approved or was banned
+	Member *WebhookUser    `json:"member,omitempty"`
+	Movies []*WebhookMovie `json:"movies,omitempty"`
+	Status *WebhookStatus  `json:"status,omitempty"`
+}
+
+type WebhookUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+type WebhookMovie struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WebhookStatus struct {
+	Seek float64 `json:"seek"`
+	Rate float64 `json:"rate"`
+}
+
+func webhookUser(u *User) *WebhookUser {
+	return &WebhookUser{
+		ID:       u.ID,
+		Username: u.Username,
+	}
+}
+
+func webhookUserByID(userID string) *WebhookUser {
+	return &WebhookUser{
+		ID:       userID,
+		Username: GetUserName(userID),
+	}
+}
+
+func webhookSender(s *pb.Sender) *WebhookUser {
+	if s == nil {
+		return nil
+	}
+	return &WebhookUser{
+		ID:       s.Userid,
+		Username: s.Username,
+	}
+}
+
+func webhookMovie(m *model.Movie) *WebhookMovie {
+	return &WebhookMovie{
+		ID:   m.ID,
+		Name: m.Base.Name,
+	}
+}
+
+func (r *Room) loadWebhooks() []*model.RoomWebhook {
+	if c := r.webhooks.Load(); c != nil && time.Since(c.loadedAt) < webhookCacheTTL {
+		return c.list
+	}
+	list, err := db.GetRoomWebhooks(r.ID)
+	if err != nil {
+		log.Errorf("room %s: load webhooks error: %v", r.ID, err)
+		return nil
+	}
+	r.webhooks.Store(&cachedWebhooks{
+		list:     list,
+		loadedAt: time.Now(),
+	})
+	return list
+}
+
+// emitWebhook queues a delivery of the event to the webhooks of the room subscribed to it
+func (r *Room) emitWebhook(event model.WebhookEvent, data WebhookData) {
+	now := time.Now()
+	queueWebhookEvent(func() {
+		r.createWebhookDeliveries(event, data, now)
+	})
+}
+
+func (r *Room) createWebhookDeliveries(event model.WebhookEvent, data WebhookData, at time.Time) {
+	var deliveries []*model.WebhookDelivery
+	for _, w := range r.loadWebhooks() {
+		if !w.Subscribed(event) {
+			continue
+		}
+		deliveries = append(deliveries, &model.WebhookDelivery{
+			WebhookID: w.ID,
+			Event:     event,
+			Status:    model.WebhookDeliveryPending,
+		})
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+	payload, err := json.Marshal(&WebhookPayload{
+		Event:  event,
+		RoomID: r.ID,
+		Time:   at.UnixMilli(),
+		Data:   data,
+	})
+	if err != nil {
+		log.Errorf("room %s: marshal webhook payload error: %v", r.ID, err)
+		return
+	}
+	now := time.Now()
+	for _, d := range deliveries {
+		d.Payload = string(payload)
+		d.NextAttemptAt = now
+	}
+	if err := db.CreateWebhookDeliveries(deliveries); err != nil {
+		log.Errorf("room %s: queue webhook deliveries error: %v", r.ID, err)
+	}
+}
+
+// broadcastWebhook emits the events of the messages broadcast by this node
+func (r *Room) broadcastWebhook(em *pb.ElementMessage) {
+	switch em.Type {
+	case pb.ElementMessageType_CURRENT_CHANGED:
+		data := WebhookData{
+			Sender: webhookSender(em.CurrentChanged),
+		}
+		now := time.Now()
+		queueWebhookEvent(func() {
+			if m, err := r.CurrentMovie(); err == nil {
+				data.Movies = []*WebhookMovie{webhookMovie(m.Movie)}
+			}
+			r.createWebhookDeliveries(model.WebhookEventCurrentChanged, data, now)
+		})
+	case pb.ElementMessageType_PLAY, pb.ElementMessageType_PAUSE:
+		event := model.WebhookEventPlay
+		if em.Type == pb.ElementMessageType_PAUSE {
+			event = model.WebhookEventPause
+		}
+		data := WebhookData{
+			Sender: webhookSender(em.MovieStatusChanged.GetSender()),
+		}
+		if s := em.MovieStatusChanged.GetStatus(); s != nil {
+			data.Status = &WebhookStatus{
+				Seek: s.Seek,
+				Rate: s.Rate,
+			}
+		}
+		r.emitWebhook(event, data)
+	}
+}
+
+// LivePublishStarted is called when a publisher starts streaming to the live movie
+func (r *Room) LivePublishStarted(movieID string) {
+	m, err := r.GetMovieByID(movieID)
+	if err != nil {
+		return
+	}
+	r.emitWebhook(model.WebhookEventLivePublishStarted, WebhookData{
+		Movies: []*WebhookMovie{webhookMovie(m.Movie)},
+	})
+}
+
+func SignWebhookPayload(secret, payload string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(payload))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func runWebhookDeliveries() {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	for range t.C {
+		list, err := db.GetDueWebhookDeliveries(time.Now(), webhookBatchSize)
+		if err != nil {
+			log.Errorf("load webhook deliveries error: %v", err)
+			continue
+		}
+		for _, d := range list {
+			// no other node sends it while it is leased
+			ok, err := db.LeaseWebhookDelivery(d, time.Now().Add(webhookTimeout*2))
+			if err != nil {
+				log.Errorf("lease webhook delivery %d error: %v", d.ID, err)
+				continue
+			}
+			if ok {
+				go deliverWebhook(d)
+			}
+		}
+	}
+}
+
+func cleanOldWebhookDeliveries() {
+	t := time.NewTicker(time.Hour)
+	defer t.Stop()
+	for range t.C {
+		if err := db.DeleteOldWebhookDeliveries(time.Now().Add(-webhookDeliveryRetention)); err != nil {
+			log.Errorf("clean old webhook deliveries error: %v", err)
+		}
+	}
+}
+
+// 10s, 20s, 40s...
+func webhookBackoff(attempts int) time.Duration {
+	return 10 * time.Second << (attempts - 1)
+}
+
+func deliverWebhook(d *model.WebhookDelivery) {
+	w, err := db.GetWebhookByID(d.WebhookID)
+	if err != nil {
+		// deleted together with its deliveries
+		return
+	}
+	d.Attempts++
+	d.StatusCode, err = postWebhook(w, d)
+	switch {
+	case err == nil:
+		d.Status = model.WebhookDeliverySucceeded
+		d.Error = ""
+	case d.Attempts >= webhookMaxAttempts:
+		d.Status = model.WebhookDeliveryFailed
+		d.Error = utils.TruncateByRune(err.Error(), 500)
+	default:
+		d.NextAttemptAt = time.Now().Add(webhookBackoff(d.Attempts))
+		d.Error = utils.TruncateByRune(err.Error(), 500)
+	}
+	if err := db.SaveWebhookDelivery(d); err != nil {
+		log.Errorf("save webhook delivery %d error: %v", d.ID, err)
+	}
+}
+
+func postWebhook(w *model.RoomWebhook, d *model.WebhookDelivery) (statusCode int, err error) {
+	req, err := http.NewRequest(http.MethodPost, w.URL, strings.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SyncTV-Webhook")
+	req.Header.Set("X-SyncTV-Event", string(d.Event))
+	req.Header.Set("X-SyncTV-Delivery", strconv.FormatUint(d.ID, 10))
+	req.Header.Set("X-SyncTV-Signature", "sha256="+SignWebhookPayload(w.Secret, d.Payload))
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (u *User) canManageRoomWebhooks(room *Room) bool {
+	return u.IsAdmin() || u.IsRoomCreator(room)
+}
+
+func (u *User) RoomWebhooks(room *Room) ([]*model.RoomWebhook, error) {
+	if !u.canManageRoomWebhooks(room) {
+		return nil, model.ErrNoPermission
+	}
+	return db.GetRoomWebhooks(room.ID)
+}
+
+// AddRoomWebhook registers the url for the events, no events means all of them
+func (u *User) AddRoomWebhook(room *Room, rawURL string, events []model.WebhookEvent) (*model.RoomWebhook, error) {
+	if !u.canManageRoomWebhooks(room) {
+		return nil, model.ErrNoPermission
+	}
+	if uu, err := url.Parse(rawURL); err != nil || uu.Host == "" || (uu.Scheme != "http" && uu.Scheme != "https") {
+		return nil, ErrInvalidWebhookURL
+	}
+	if !settings.AllowProxyToLocal.Get() {
+		if l, err := utils.ParseURLIsLocalIP(rawURL); err != nil {
+			return nil, fmt.Errorf("check url is local ip error: %w", err)
+		} else if l {
+			return nil, ErrLocalWebhookURL
+		}
+	}
+	for _, e := range events {
+		if !utils.In(model.WebhookEvents, e) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownWebhookEvent, e)
+		}
+	}
+	w := &model.RoomWebhook{
+		RoomID:    room.ID,
+		CreatorID: u.ID,
+		URL:       rawURL,
+		Secret:    utils.RandString(32),
+		Events:    events,
+	}
+	if err := db.CreateRoomWebhook(w); err != nil {
+		return nil, err
+	}
+	room.webhooks.Store(nil)
+	return w, nil
+}
+
+func (u *User) DeleteRoomWebhook(room *Room, id string) error {
+	if !u.canManageRoomWebhooks(room) {
+		return model.ErrNoPermission
+	}
+	if err := db.DeleteRoomWebhook(room.ID, id); err != nil {
+		return err
+	}
+	room.webhooks.Store(nil)
+	return nil
+}
+
+func (u *User) RoomWebhookDeliveries(room *Room, id string, page, pageSize int) ([]*model.WebhookDelivery, int64, error) {
+	if !u.canManageRoomWebhooks(room) {
+		return nil, 0, model.ErrNoPermission
+	}
+	if _, err := db.GetRoomWebhook(room.ID, id); err != nil {
+		return nil, 0, err
+	}
+	return db.GetWebhookDeliveries(id, page, pageSize)
+}
@@ -0,0 +1,76 @@
+package op
+
+import (
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/model"
+	pb "github.com/synctv-org/synctv/proto/message"
+)
+
+var ErrWhisperDisabled = errors.New("whisper is disabled in this room")
+
+// SendWhisper sends a private message to the clients of the target and the sender
+func (c *Client) SendWhisper(targetID, message string) error {
+	if !c.u.HasRoomPermission(c.r, model.PermissionSendChatMessage) {
+		return model.ErrNoPermission
+	}
+	if c.r.Settings.DisableWhisper {
+		return ErrWhisperDisabled
+	}
+	// all guests share the same user
+	if c.u.IsGuest() || c.r.IsGuest(targetID) {
+		return errors.New("guest cannot whisper")
+	}
+	if targetID == c.u.ID {
+		return errors.New("cannot whisper to yourself")
+	}
+	status, err := c.r.LoadMemberStatus(targetID)
+	if err != nil {
+		return err
+	}
+	if status.IsNotActive() {
+		return errors.New("user is not an active member")
+	}
+	if err := c.r.checkChatMessage(c.u, message); err != nil {
+		return err
+	}
+	now := time.Now()
+	if !c.r.Settings.DisableChatHistory {
+		err := db.CreateChatMessage(&model.ChatMessage{
+			CreatedAt:  now,
+			RoomID:     c.r.ID,
+			SenderID:   c.u.ID,
+			ReceiverID: targetID,
+			Message:    message,
+		})
+		if err != nil {
+			log.Errorf("save whisper error: %v", err)
+		}
+	}
+	msg := newWhisperMessage(now, c.u.ID, c.u.Username, targetID, message)
+	if err := c.r.hub.SendToUser(targetID, msg); err != nil {
+		return err
+	}
+	return c.r.hub.SendToUser(c.u.ID, msg)
+}
+
+func newWhisperMessage(t time.Time, senderID, senderName, targetID, message string) *pb.ElementMessage {
+	return &pb.ElementMessage{
+		Type: pb.ElementMessageType_WHISPER,
+		Time: t.UnixMilli(),
+		Whisper: &pb.Whisper{
+			Sender: &pb.Sender{
+				Userid:   senderID,
+				Username: senderName,
+			},
+			Target: &pb.Sender{
+				Userid:   targetID,
+				Username: GetUserName(targetID),
+			},
+			Message: message,
+		},
+	}
+}
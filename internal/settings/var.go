@@ -5,6 +5,7 @@ import (
 
 	"github.com/synctv-org/synctv/internal/db"
 	"github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/utils"
 )
 
 var (
@@ -14,6 +15,11 @@ var (
 	CreateRoomNeedReview = NewBoolSetting("create_room_need_review", false, model.SettingGroupRoom)
 	// 48 hours
 	RoomTTL = NewInt64Setting("room_ttl", 48, model.SettingGroupRoom)
+	// blocked words and /regexps/ of the chat in all rooms, one per line
+	ChatBlocklist = NewStringSetting("chat_blocklist", "", model.SettingGroupRoom, WithBeforeSetString(func(ss StringSetting, s string) (string, error) {
+		_, err := utils.ParseBlocklist(s)
+		return s, err
+	}))
 )
 
 func init() {
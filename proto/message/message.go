@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -19,3 +20,21 @@ func (em *ElementMessage) Encode(w io.Writer) error {
 	_, err = w.Write(b)
 	return err
 }
+
+// JSONElementMessage is the ElementMessage encoded with protojson in text frames
+type JSONElementMessage struct {
+	*ElementMessage
+}
+
+func (em *JSONElementMessage) MessageType() int {
+	return websocket.TextMessage
+}
+
+func (em *JSONElementMessage) Encode(w io.Writer) error {
+	b, err := protojson.Marshal(em.ElementMessage)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
@@ -23,20 +23,39 @@ const (
 type ElementMessageType int32
 
 const (
-	ElementMessageType_UNKNOWN           ElementMessageType = 0
-	ElementMessageType_ERROR             ElementMessageType = 1
-	ElementMessageType_CHAT_MESSAGE      ElementMessageType = 2
-	ElementMessageType_PLAY              ElementMessageType = 3
-	ElementMessageType_PAUSE             ElementMessageType = 4
-	ElementMessageType_CHECK             ElementMessageType = 5
-	ElementMessageType_TOO_FAST          ElementMessageType = 6
-	ElementMessageType_TOO_SLOW          ElementMessageType = 7
-	ElementMessageType_CHANGE_RATE       ElementMessageType = 8
-	ElementMessageType_CHANGE_SEEK       ElementMessageType = 9
-	ElementMessageType_CURRENT_CHANGED   ElementMessageType = 10
-	ElementMessageType_MOVIES_CHANGED    ElementMessageType = 11
-	ElementMessageType_PEOPLE_CHANGED    ElementMessageType = 12
-	ElementMessageType_SYNC_MOVIE_STATUS ElementMessageType = 13
+	ElementMessageType_UNKNOWN            ElementMessageType = 0
+	ElementMessageType_ERROR              ElementMessageType = 1
+	ElementMessageType_CHAT_MESSAGE       ElementMessageType = 2
+	ElementMessageType_PLAY               ElementMessageType = 3
+	ElementMessageType_PAUSE              ElementMessageType = 4
+	ElementMessageType_CHECK              ElementMessageType = 5
+	ElementMessageType_TOO_FAST           ElementMessageType = 6
+	ElementMessageType_TOO_SLOW           ElementMessageType = 7
+	ElementMessageType_CHANGE_RATE        ElementMessageType = 8
+	ElementMessageType_CHANGE_SEEK        ElementMessageType = 9
+	ElementMessageType_CURRENT_CHANGED    ElementMessageType = 10
+	ElementMessageType_MOVIES_CHANGED     ElementMessageType = 11
+	ElementMessageType_PEOPLE_CHANGED     ElementMessageType = 12
+	ElementMessageType_SYNC_MOVIE_STATUS  ElementMessageType = 13
+	ElementMessageType_TIME_SYNC          ElementMessageType = 14
+	ElementMessageType_DRIFT_CORRECTION   ElementMessageType = 15
+	ElementMessageType_HOST_CHANGED       ElementMessageType = 16
+	ElementMessageType_PRESENCE_JOIN      ElementMessageType = 17
+	ElementMessageType_PRESENCE_LEAVE     ElementMessageType = 18
+	ElementMessageType_PRESENCE_UPDATE    ElementMessageType = 19
+	ElementMessageType_BUFFERING_START    ElementMessageType = 20
+	ElementMessageType_BUFFERING_END      ElementMessageType = 21
+	ElementMessageType_RESYNC             ElementMessageType = 22
+	ElementMessageType_DANMAKU            ElementMessageType = 23
+	ElementMessageType_WHISPER            ElementMessageType = 24
+	ElementMessageType_CHAT_RETRACTED     ElementMessageType = 25
+	ElementMessageType_POLL_STARTED       ElementMessageType = 26
+	ElementMessageType_POLL_VOTE          ElementMessageType = 27
+	ElementMessageType_POLL_UPDATED       ElementMessageType = 28
+	ElementMessageType_POLL_ENDED         ElementMessageType = 29
+	ElementMessageType_ENDED              ElementMessageType = 30
+	ElementMessageType_SCHEDULE_COUNTDOWN ElementMessageType = 31
+	ElementMessageType_SCHEDULES_CHANGED  ElementMessageType = 32
 )
 
 // Enum value maps for ElementMessageType.
@@ -56,22 +75,60 @@ var (
 		11: "MOVIES_CHANGED",
 		12: "PEOPLE_CHANGED",
 		13: "SYNC_MOVIE_STATUS",
+		14: "TIME_SYNC",
+		15: "DRIFT_CORRECTION",
+		16: "HOST_CHANGED",
+		17: "PRESENCE_JOIN",
+		18: "PRESENCE_LEAVE",
+		19: "PRESENCE_UPDATE",
+		20: "BUFFERING_START",
+		21: "BUFFERING_END",
+		22: "RESYNC",
+		23: "DANMAKU",
+		24: "WHISPER",
+		25: "CHAT_RETRACTED",
+		26: "POLL_STARTED",
+		27: "POLL_VOTE",
+		28: "POLL_UPDATED",
+		29: "POLL_ENDED",
+		30: "ENDED",
+		31: "SCHEDULE_COUNTDOWN",
+		32: "SCHEDULES_CHANGED",
 	}
 	ElementMessageType_value = map[string]int32{
-		"UNKNOWN":           0,
-		"ERROR":             1,
-		"CHAT_MESSAGE":      2,
-		"PLAY":              3,
-		"PAUSE":             4,
-		"CHECK":             5,
-		"TOO_FAST":          6,
-		"TOO_SLOW":          7,
-		"CHANGE_RATE":       8,
-		"CHANGE_SEEK":       9,
-		"CURRENT_CHANGED":   10,
-		"MOVIES_CHANGED":    11,
-		"PEOPLE_CHANGED":    12,
-		"SYNC_MOVIE_STATUS": 13,
+		"UNKNOWN":            0,
+		"ERROR":              1,
+		"CHAT_MESSAGE":       2,
+		"PLAY":               3,
+		"PAUSE":              4,
+		"CHECK":              5,
+		"TOO_FAST":           6,
+		"TOO_SLOW":           7,
+		"CHANGE_RATE":        8,
+		"CHANGE_SEEK":        9,
+		"CURRENT_CHANGED":    10,
+		"MOVIES_CHANGED":     11,
+		"PEOPLE_CHANGED":     12,
+		"SYNC_MOVIE_STATUS":  13,
+		"TIME_SYNC":          14,
+		"DRIFT_CORRECTION":   15,
+		"HOST_CHANGED":       16,
+		"PRESENCE_JOIN":      17,
+		"PRESENCE_LEAVE":     18,
+		"PRESENCE_UPDATE":    19,
+		"BUFFERING_START":    20,
+		"BUFFERING_END":      21,
+		"RESYNC":             22,
+		"DANMAKU":            23,
+		"WHISPER":            24,
+		"CHAT_RETRACTED":     25,
+		"POLL_STARTED":       26,
+		"POLL_VOTE":          27,
+		"POLL_UPDATED":       28,
+		"POLL_ENDED":         29,
+		"ENDED":              30,
+		"SCHEDULE_COUNTDOWN": 31,
+		"SCHEDULES_CHANGED":  32,
 	}
 )
 
@@ -109,6 +166,7 @@ type ChatResp struct {
 
 	Sender  *Sender `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
 	Message string  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Id      uint64  `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
 }
 
 func (x *ChatResp) Reset() {
@@ -157,6 +215,13 @@ func (x *ChatResp) GetMessage() string {
 	return ""
 }
 
+func (x *ChatResp) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type Sender struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -231,76 +296,764 @@ func (x *MovieStatus) Reset() {
 	}
 }
 
-func (x *MovieStatus) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *MovieStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieStatus) ProtoMessage() {}
+
+func (x *MovieStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieStatus.ProtoReflect.Descriptor instead.
+func (*MovieStatus) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MovieStatus) GetPlaying() bool {
+	if x != nil {
+		return x.Playing
+	}
+	return false
+}
+
+func (x *MovieStatus) GetSeek() float64 {
+	if x != nil {
+		return x.Seek
+	}
+	return 0
+}
+
+func (x *MovieStatus) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+type MovieStatusChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sender *Sender      `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	Status *MovieStatus `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *MovieStatusChanged) Reset() {
+	*x = MovieStatusChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_message_message_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MovieStatusChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovieStatusChanged) ProtoMessage() {}
+
+func (x *MovieStatusChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovieStatusChanged.ProtoReflect.Descriptor instead.
+func (*MovieStatusChanged) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MovieStatusChanged) GetSender() *Sender {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *MovieStatusChanged) GetStatus() *MovieStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type CheckReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   *MovieStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	ExpireId uint64       `protobuf:"varint,2,opt,name=expireId,proto3" json:"expireId,omitempty"`
+	// ignored, the buffering is reported by BUFFERING_START and BUFFERING_END
+	Buffering bool `protobuf:"varint,3,opt,name=buffering,proto3" json:"buffering,omitempty"`
+}
+
+func (x *CheckReq) Reset() {
+	*x = CheckReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_message_message_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckReq) ProtoMessage() {}
+
+func (x *CheckReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckReq.ProtoReflect.Descriptor instead.
+func (*CheckReq) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckReq) GetStatus() *MovieStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *CheckReq) GetExpireId() uint64 {
+	if x != nil {
+		return x.ExpireId
+	}
+	return 0
+}
+
+func (x *CheckReq) GetBuffering() bool {
+	if x != nil {
+		return x.Buffering
+	}
+	return false
+}
+
+type TimeSyncReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientTime int64 `protobuf:"varint,1,opt,name=clientTime,proto3" json:"clientTime,omitempty"`
+	Offset     int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Rtt        int64 `protobuf:"varint,3,opt,name=rtt,proto3" json:"rtt,omitempty"`
+}
+
+func (x *TimeSyncReq) Reset() {
+	*x = TimeSyncReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_message_message_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeSyncReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeSyncReq) ProtoMessage() {}
+
+func (x *TimeSyncReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSyncReq.ProtoReflect.Descriptor instead.
+func (*TimeSyncReq) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TimeSyncReq) GetClientTime() int64 {
+	if x != nil {
+		return x.ClientTime
+	}
+	return 0
+}
+
+func (x *TimeSyncReq) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *TimeSyncReq) GetRtt() int64 {
+	if x != nil {
+		return x.Rtt
+	}
+	return 0
+}
+
+type TimeSyncResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientTime        int64 `protobuf:"varint,1,opt,name=clientTime,proto3" json:"clientTime,omitempty"`
+	ServerReceiveTime int64 `protobuf:"varint,2,opt,name=serverReceiveTime,proto3" json:"serverReceiveTime,omitempty"`
+	ServerSendTime    int64 `protobuf:"varint,3,opt,name=serverSendTime,proto3" json:"serverSendTime,omitempty"`
+}
+
+func (x *TimeSyncResp) Reset() {
+	*x = TimeSyncResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_message_message_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeSyncResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeSyncResp) ProtoMessage() {}
+
+func (x *TimeSyncResp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSyncResp.ProtoReflect.Descriptor instead.
+func (*TimeSyncResp) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TimeSyncResp) GetClientTime() int64 {
+	if x != nil {
+		return x.ClientTime
+	}
+	return 0
+}
+
+func (x *TimeSyncResp) GetServerReceiveTime() int64 {
+	if x != nil {
+		return x.ServerReceiveTime
+	}
+	return 0
+}
+
+func (x *TimeSyncResp) GetServerSendTime() int64 {
+	if x != nil {
+		return x.ServerSendTime
+	}
+	return 0
+}
+
+type DriftCorrection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Drift    float64 `protobuf:"fixed64,1,opt,name=drift,proto3" json:"drift,omitempty"`
+	Rate     float64 `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	Duration float64 `protobuf:"fixed64,3,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *DriftCorrection) Reset() {
+	*x = DriftCorrection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_message_message_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DriftCorrection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriftCorrection) ProtoMessage() {}
+
+func (x *DriftCorrection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriftCorrection.ProtoReflect.Descriptor instead.
+func (*DriftCorrection) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DriftCorrection) GetDrift() float64 {
+	if x != nil {
+		return x.Drift
+	}
+	return 0
+}
+
+func (x *DriftCorrection) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *DriftCorrection) GetDuration() float64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type Presence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User      *Sender `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Devices   int64   `protobuf:"varint,2,opt,name=devices,proto3" json:"devices,omitempty"`
+	Playing   bool    `protobuf:"varint,3,opt,name=playing,proto3" json:"playing,omitempty"`
+	Seek      float64 `protobuf:"fixed64,4,opt,name=seek,proto3" json:"seek,omitempty"`
+	Buffering bool    `protobuf:"varint,5,opt,name=buffering,proto3" json:"buffering,omitempty"`
+	Drift     float64 `protobuf:"fixed64,6,opt,name=drift,proto3" json:"drift,omitempty"`
+	CheckedAt int64   `protobuf:"varint,7,opt,name=checkedAt,proto3" json:"checkedAt,omitempty"`
+}
+
+func (x *Presence) Reset() {
+	*x = Presence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_message_message_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Presence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Presence) ProtoMessage() {}
+
+func (x *Presence) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Presence.ProtoReflect.Descriptor instead.
+func (*Presence) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Presence) GetUser() *Sender {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Presence) GetDevices() int64 {
+	if x != nil {
+		return x.Devices
+	}
+	return 0
+}
+
+func (x *Presence) GetPlaying() bool {
+	if x != nil {
+		return x.Playing
+	}
+	return false
+}
+
+func (x *Presence) GetSeek() float64 {
+	if x != nil {
+		return x.Seek
+	}
+	return 0
+}
+
+func (x *Presence) GetBuffering() bool {
+	if x != nil {
+		return x.Buffering
+	}
+	return false
+}
+
+func (x *Presence) GetDrift() float64 {
+	if x != nil {
+		return x.Drift
+	}
+	return 0
+}
+
+func (x *Presence) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+type Danmaku struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sender  *Sender `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	MovieId string  `protobuf:"bytes,2,opt,name=movieId,proto3" json:"movieId,omitempty"`
+	Time    float64 `protobuf:"fixed64,3,opt,name=time,proto3" json:"time,omitempty"`
+	Content string  `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Color   uint32  `protobuf:"varint,5,opt,name=color,proto3" json:"color,omitempty"`
+	Mode    uint32  `protobuf:"varint,6,opt,name=mode,proto3" json:"mode,omitempty"`
+}
+
+func (x *Danmaku) Reset() {
+	*x = Danmaku{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_message_message_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Danmaku) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Danmaku) ProtoMessage() {}
+
+func (x *Danmaku) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Danmaku.ProtoReflect.Descriptor instead.
+func (*Danmaku) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Danmaku) GetSender() *Sender {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *Danmaku) GetMovieId() string {
+	if x != nil {
+		return x.MovieId
+	}
+	return ""
+}
+
+func (x *Danmaku) GetTime() float64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Danmaku) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Danmaku) GetColor() uint32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *Danmaku) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+type Whisper struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sender  *Sender `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	Target  *Sender `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Message string  `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Whisper) Reset() {
+	*x = Whisper{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_message_message_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Whisper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Whisper) ProtoMessage() {}
+
+func (x *Whisper) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Whisper.ProtoReflect.Descriptor instead.
+func (*Whisper) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Whisper) GetSender() *Sender {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *Whisper) GetTarget() *Sender {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *Whisper) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Poll struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind      string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Question  string   `protobuf:"bytes,3,opt,name=question,proto3" json:"question,omitempty"`
+	Options   []string `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	Votes     []int64  `protobuf:"varint,5,rep,packed,name=votes,proto3" json:"votes,omitempty"`
+	Voters    int64    `protobuf:"varint,6,opt,name=voters,proto3" json:"voters,omitempty"`
+	Quorum    float64  `protobuf:"fixed64,7,opt,name=quorum,proto3" json:"quorum,omitempty"`
+	ExpiresAt int64    `protobuf:"varint,8,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	Creator   *Sender  `protobuf:"bytes,9,opt,name=creator,proto3" json:"creator,omitempty"`
+	MovieId   string   `protobuf:"bytes,10,opt,name=movieId,proto3" json:"movieId,omitempty"`
+	Result    int32    `protobuf:"varint,11,opt,name=result,proto3" json:"result,omitempty"`
+	Ended     bool     `protobuf:"varint,12,opt,name=ended,proto3" json:"ended,omitempty"`
+}
+
+func (x *Poll) Reset() {
+	*x = Poll{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_message_message_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Poll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Poll) ProtoMessage() {}
+
+func (x *Poll) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Poll.ProtoReflect.Descriptor instead.
+func (*Poll) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Poll) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Poll) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Poll) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *Poll) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Poll) GetVotes() []int64 {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+func (x *Poll) GetVoters() int64 {
+	if x != nil {
+		return x.Voters
+	}
+	return 0
+}
+
+func (x *Poll) GetQuorum() float64 {
+	if x != nil {
+		return x.Quorum
+	}
+	return 0
 }
 
-func (*MovieStatus) ProtoMessage() {}
-
-func (x *MovieStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_message_message_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Poll) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use MovieStatus.ProtoReflect.Descriptor instead.
-func (*MovieStatus) Descriptor() ([]byte, []int) {
-	return file_proto_message_message_proto_rawDescGZIP(), []int{2}
+func (x *Poll) GetCreator() *Sender {
+	if x != nil {
+		return x.Creator
+	}
+	return nil
 }
 
-func (x *MovieStatus) GetPlaying() bool {
+func (x *Poll) GetMovieId() string {
 	if x != nil {
-		return x.Playing
+		return x.MovieId
 	}
-	return false
+	return ""
 }
 
-func (x *MovieStatus) GetSeek() float64 {
+func (x *Poll) GetResult() int32 {
 	if x != nil {
-		return x.Seek
+		return x.Result
 	}
 	return 0
 }
 
-func (x *MovieStatus) GetRate() float64 {
+func (x *Poll) GetEnded() bool {
 	if x != nil {
-		return x.Rate
+		return x.Ended
 	}
-	return 0
+	return false
 }
 
-type MovieStatusChanged struct {
+type PollVote struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sender *Sender      `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
-	Status *MovieStatus `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	PollId string `protobuf:"bytes,1,opt,name=pollId,proto3" json:"pollId,omitempty"`
+	Option int32  `protobuf:"varint,2,opt,name=option,proto3" json:"option,omitempty"`
 }
 
-func (x *MovieStatusChanged) Reset() {
-	*x = MovieStatusChanged{}
+func (x *PollVote) Reset() {
+	*x = PollVote{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_message_message_proto_msgTypes[3]
+		mi := &file_proto_message_message_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MovieStatusChanged) String() string {
+func (x *PollVote) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MovieStatusChanged) ProtoMessage() {}
+func (*PollVote) ProtoMessage() {}
 
-func (x *MovieStatusChanged) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_message_message_proto_msgTypes[3]
+func (x *PollVote) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,51 +1064,54 @@ func (x *MovieStatusChanged) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MovieStatusChanged.ProtoReflect.Descriptor instead.
-func (*MovieStatusChanged) Descriptor() ([]byte, []int) {
-	return file_proto_message_message_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use PollVote.ProtoReflect.Descriptor instead.
+func (*PollVote) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *MovieStatusChanged) GetSender() *Sender {
+func (x *PollVote) GetPollId() string {
 	if x != nil {
-		return x.Sender
+		return x.PollId
 	}
-	return nil
+	return ""
 }
 
-func (x *MovieStatusChanged) GetStatus() *MovieStatus {
+func (x *PollVote) GetOption() int32 {
 	if x != nil {
-		return x.Status
+		return x.Option
 	}
-	return nil
+	return 0
 }
 
-type CheckReq struct {
+type Schedule struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status   *MovieStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	ExpireId uint64       `protobuf:"varint,2,opt,name=expireId,proto3" json:"expireId,omitempty"`
+	Id      string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MovieId string  `protobuf:"bytes,2,opt,name=movieId,proto3" json:"movieId,omitempty"`
+	Title   string  `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	StartAt int64   `protobuf:"varint,4,opt,name=startAt,proto3" json:"startAt,omitempty"`
+	Creator *Sender `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`
 }
 
-func (x *CheckReq) Reset() {
-	*x = CheckReq{}
+func (x *Schedule) Reset() {
+	*x = Schedule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_message_message_proto_msgTypes[4]
+		mi := &file_proto_message_message_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CheckReq) String() string {
+func (x *Schedule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckReq) ProtoMessage() {}
+func (*Schedule) ProtoMessage() {}
 
-func (x *CheckReq) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_message_message_proto_msgTypes[4]
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_message_message_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,25 +1122,46 @@ func (x *CheckReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckReq.ProtoReflect.Descriptor instead.
-func (*CheckReq) Descriptor() ([]byte, []int) {
-	return file_proto_message_message_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_proto_message_message_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *CheckReq) GetStatus() *MovieStatus {
+func (x *Schedule) GetId() string {
 	if x != nil {
-		return x.Status
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *CheckReq) GetExpireId() uint64 {
+func (x *Schedule) GetMovieId() string {
 	if x != nil {
-		return x.ExpireId
+		return x.MovieId
+	}
+	return ""
+}
+
+func (x *Schedule) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Schedule) GetStartAt() int64 {
+	if x != nil {
+		return x.StartAt
 	}
 	return 0
 }
 
+func (x *Schedule) GetCreator() *Sender {
+	if x != nil {
+		return x.Creator
+	}
+	return nil
+}
+
 type ElementMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -402,12 +1179,26 @@ type ElementMessage struct {
 	PeopleChanged        int64               `protobuf:"varint,11,opt,name=peopleChanged,proto3" json:"peopleChanged,omitempty"`
 	MoviesChanged        *Sender             `protobuf:"bytes,12,opt,name=moviesChanged,proto3" json:"moviesChanged,omitempty"`
 	CurrentChanged       *Sender             `protobuf:"bytes,13,opt,name=currentChanged,proto3" json:"currentChanged,omitempty"`
+	TimeSyncReq          *TimeSyncReq        `protobuf:"bytes,14,opt,name=timeSyncReq,proto3" json:"timeSyncReq,omitempty"`
+	TimeSyncResp         *TimeSyncResp       `protobuf:"bytes,15,opt,name=timeSyncResp,proto3" json:"timeSyncResp,omitempty"`
+	DriftCorrection      *DriftCorrection    `protobuf:"bytes,16,opt,name=driftCorrection,proto3" json:"driftCorrection,omitempty"`
+	HostChanged          *Sender             `protobuf:"bytes,17,opt,name=hostChanged,proto3" json:"hostChanged,omitempty"`
+	Presence             *Presence           `protobuf:"bytes,18,opt,name=presence,proto3" json:"presence,omitempty"`
+	Seq                  uint64              `protobuf:"varint,19,opt,name=seq,proto3" json:"seq,omitempty"`
+	Danmaku              *Danmaku            `protobuf:"bytes,20,opt,name=danmaku,proto3" json:"danmaku,omitempty"`
+	Whisper              *Whisper            `protobuf:"bytes,21,opt,name=whisper,proto3" json:"whisper,omitempty"`
+	RetractedChatId      uint64              `protobuf:"varint,22,opt,name=retractedChatId,proto3" json:"retractedChatId,omitempty"`
+	Poll                 *Poll               `protobuf:"bytes,23,opt,name=poll,proto3" json:"poll,omitempty"`
+	PollVote             *PollVote           `protobuf:"bytes,24,opt,name=pollVote,proto3" json:"pollVote,omitempty"`
+	EndedMovieId         string              `protobuf:"bytes,25,opt,name=endedMovieId,proto3" json:"endedMovieId,omitempty"`
+	Schedule             *Schedule           `protobuf:"bytes,26,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	SchedulesChanged     *Sender             `protobuf:"bytes,27,opt,name=schedulesChanged,proto3" json:"schedulesChanged,omitempty"`
 }
 
 func (x *ElementMessage) Reset() {
 	*x = ElementMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_message_message_proto_msgTypes[5]
+		mi := &file_proto_message_message_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -420,7 +1211,7 @@ func (x *ElementMessage) String() string {
 func (*ElementMessage) ProtoMessage() {}
 
 func (x *ElementMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_message_message_proto_msgTypes[5]
+	mi := &file_proto_message_message_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +1224,7 @@ func (x *ElementMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ElementMessage.ProtoReflect.Descriptor instead.
 func (*ElementMessage) Descriptor() ([]byte, []int) {
-	return file_proto_message_message_proto_rawDescGZIP(), []int{5}
+	return file_proto_message_message_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ElementMessage) GetType() ElementMessageType {
@@ -520,17 +1311,116 @@ func (x *ElementMessage) GetCurrentChanged() *Sender {
 	return nil
 }
 
+func (x *ElementMessage) GetTimeSyncReq() *TimeSyncReq {
+	if x != nil {
+		return x.TimeSyncReq
+	}
+	return nil
+}
+
+func (x *ElementMessage) GetTimeSyncResp() *TimeSyncResp {
+	if x != nil {
+		return x.TimeSyncResp
+	}
+	return nil
+}
+
+func (x *ElementMessage) GetDriftCorrection() *DriftCorrection {
+	if x != nil {
+		return x.DriftCorrection
+	}
+	return nil
+}
+
+func (x *ElementMessage) GetHostChanged() *Sender {
+	if x != nil {
+		return x.HostChanged
+	}
+	return nil
+}
+
+func (x *ElementMessage) GetPresence() *Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return nil
+}
+
+func (x *ElementMessage) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ElementMessage) GetDanmaku() *Danmaku {
+	if x != nil {
+		return x.Danmaku
+	}
+	return nil
+}
+
+func (x *ElementMessage) GetWhisper() *Whisper {
+	if x != nil {
+		return x.Whisper
+	}
+	return nil
+}
+
+func (x *ElementMessage) GetRetractedChatId() uint64 {
+	if x != nil {
+		return x.RetractedChatId
+	}
+	return 0
+}
+
+func (x *ElementMessage) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *ElementMessage) GetPollVote() *PollVote {
+	if x != nil {
+		return x.PollVote
+	}
+	return nil
+}
+
+func (x *ElementMessage) GetEndedMovieId() string {
+	if x != nil {
+		return x.EndedMovieId
+	}
+	return ""
+}
+
+func (x *ElementMessage) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *ElementMessage) GetSchedulesChanged() *Sender {
+	if x != nil {
+		return x.SchedulesChanged
+	}
+	return nil
+}
+
 var File_proto_message_message_proto protoreflect.FileDescriptor
 
 var file_proto_message_message_proto_rawDesc = []byte{
 	0x0a, 0x1b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2f,
 	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4b, 0x0a, 0x08, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5b, 0x0a, 0x08, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70,
 	0x12, 0x25, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52,
 	0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
 	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x3c, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x3c, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x75,
 	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
 	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69,
 	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x22,
@@ -545,63 +1435,208 @@ var file_proto_message_message_proto_rawDesc = []byte{
 	0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x2a, 0x0a,
 	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x52, 0x0a, 0x08, 0x43, 0x68, 0x65,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x70, 0x0a, 0x08, 0x43, 0x68, 0x65,
 	0x63, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f,
 	0x76, 0x69, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
 	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x49, 0x64, 0x22, 0xa8, 0x04,
-	0x0a, 0x0e, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74,
-	0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61,
-	0x74, 0x52, 0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x74,
-	0x52, 0x65, 0x71, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68,
-	0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x12, 0x46, 0x0a, 0x14, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x14, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x12, 0x49, 0x0a, 0x12, 0x6d, 0x6f, 0x76, 0x69,
-	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x76,
-	0x69, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52,
-	0x12, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x65,
-	0x6b, 0x52, 0x65, 0x71, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x53, 0x65, 0x65, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x68, 0x65,
-	0x63, 0x6b, 0x52, 0x65, 0x71, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x52, 0x08, 0x63, 0x68,
-	0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x65, 0x6f, 0x70, 0x6c, 0x65,
-	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x70,
-	0x65, 0x6f, 0x70, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x0d,
-	0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64,
-	0x65, 0x72, 0x52, 0x0d, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x64, 0x12, 0x35, 0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e,
-	0x67, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x2a, 0xf0, 0x01, 0x0a, 0x12, 0x45, 0x6c, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05,
-	0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x48, 0x41, 0x54, 0x5f,
-	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4c, 0x41,
-	0x59, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x55, 0x53, 0x45, 0x10, 0x04, 0x12, 0x09,
-	0x0a, 0x05, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x4f, 0x4f,
-	0x5f, 0x46, 0x41, 0x53, 0x54, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x4f, 0x4f, 0x5f, 0x53,
-	0x4c, 0x4f, 0x57, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f,
-	0x52, 0x41, 0x54, 0x45, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45,
-	0x5f, 0x53, 0x45, 0x45, 0x4b, 0x10, 0x09, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x55, 0x52, 0x52, 0x45,
-	0x4e, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x0a, 0x12, 0x12, 0x0a, 0x0e,
-	0x4d, 0x4f, 0x56, 0x49, 0x45, 0x53, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x0b,
-	0x12, 0x12, 0x0a, 0x0e, 0x50, 0x45, 0x4f, 0x50, 0x4c, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47,
-	0x45, 0x44, 0x10, 0x0c, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x59, 0x4e, 0x43, 0x5f, 0x4d, 0x4f, 0x56,
-	0x49, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x0d, 0x42, 0x06, 0x5a, 0x04, 0x2e,
-	0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x22, 0x57, 0x0a, 0x0b, 0x54,
+	0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x74, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x72, 0x74, 0x74, 0x22, 0x84, 0x01, 0x0a, 0x0c, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e,
+	0x63, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x11, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x65, 0x6e,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x57, 0x0a, 0x0f, 0x44,
+	0x72, 0x69, 0x66, 0x74, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x64, 0x72, 0x69, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x64,
+	0x72, 0x69, 0x66, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xc7, 0x01, 0x0a, 0x08, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x21, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x70, 0x6c, 0x61, 0x79, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x6b,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x73, 0x65, 0x65, 0x6b, 0x12, 0x1c, 0x0a, 0x09,
+	0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x72,
+	0x69, 0x66, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x64, 0x72, 0x69, 0x66, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x41, 0x74, 0x22, 0xa2,
+	0x01, 0x0a, 0x07, 0x44, 0x61, 0x6e, 0x6d, 0x61, 0x6b, 0x75, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c,
+	0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6d,
+	0x6f, 0x64, 0x65, 0x22, 0x71, 0x0a, 0x07, 0x57, 0x68, 0x69, 0x73, 0x70, 0x65, 0x72, 0x12, 0x25,
+	0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x06, 0x73,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xb5, 0x02, 0x0a, 0x04, 0x50, 0x6f, 0x6c, 0x6c, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x18, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x03, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x12,
+	0x1c, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x27, 0x0a,
+	0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x07, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x49,
+	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6e, 0x64, 0x65,
+	0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x22, 0x3a,
+	0x0a, 0x08, 0x50, 0x6f, 0x6c, 0x6c, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f,
+	0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x6c,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x08, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x76, 0x69, 0x65,
+	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41,
+	0x74, 0x12, 0x27, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x22, 0xa1, 0x09, 0x0a, 0x0e, 0x45,
+	0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x74, 0x52, 0x65,
+	0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x74, 0x52, 0x65, 0x71,
+	0x12, 0x2b, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x46, 0x0a,
+	0x14, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x14, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x12, 0x49, 0x0a, 0x12, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x76, 0x69, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52, 0x12, 0x6d, 0x6f,
+	0x76, 0x69, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x12, 0x24, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x65, 0x6b, 0x52, 0x65,
+	0x71, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53,
+	0x65, 0x65, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x2b, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x65, 0x6f, 0x70, 0x6c, 0x65, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x65, 0x6f, 0x70,
+	0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x0d, 0x6d, 0x6f, 0x76,
+	0x69, 0x65, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52,
+	0x0d, 0x6d, 0x6f, 0x76, 0x69, 0x65, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x35,
+	0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e,
+	0x63, 0x52, 0x65, 0x71, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x52, 0x0b,
+	0x74, 0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x12, 0x37, 0x0a, 0x0c, 0x74,
+	0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x79,
+	0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x52, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x40, 0x0a, 0x0f, 0x64, 0x72, 0x69, 0x66, 0x74, 0x43, 0x6f, 0x72,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x72, 0x69, 0x66, 0x74, 0x43, 0x6f, 0x72, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x64, 0x72, 0x69, 0x66, 0x74, 0x43, 0x6f, 0x72, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x0b, 0x68, 0x6f, 0x73, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x0b, 0x68, 0x6f, 0x73, 0x74,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x73, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x70, 0x72, 0x65, 0x73,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x28, 0x0a, 0x07, 0x64, 0x61, 0x6e, 0x6d, 0x61, 0x6b,
+	0x75, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x61, 0x6e, 0x6d, 0x61, 0x6b, 0x75, 0x52, 0x07, 0x64, 0x61, 0x6e, 0x6d, 0x61, 0x6b, 0x75,
+	0x12, 0x28, 0x0a, 0x07, 0x77, 0x68, 0x69, 0x73, 0x70, 0x65, 0x72, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x69, 0x73, 0x70, 0x65,
+	0x72, 0x52, 0x07, 0x77, 0x68, 0x69, 0x73, 0x70, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x65, 0x64, 0x43, 0x68, 0x61, 0x74, 0x49, 0x64, 0x18, 0x16, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0f, 0x72, 0x65, 0x74, 0x72, 0x61, 0x63, 0x74, 0x65, 0x64, 0x43, 0x68,
+	0x61, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x04, 0x70, 0x6f, 0x6c, 0x6c, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x52,
+	0x04, 0x70, 0x6f, 0x6c, 0x6c, 0x12, 0x2b, 0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x6c, 0x56, 0x6f, 0x74,
+	0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x50, 0x6f, 0x6c, 0x6c, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x6c, 0x56, 0x6f,
+	0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4d, 0x6f, 0x76, 0x69, 0x65,
+	0x49, 0x64, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4d,
+	0x6f, 0x76, 0x69, 0x65, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x10, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x10, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x2a, 0xc2,
+	0x04, 0x0a, 0x12, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x10, 0x0a,
+	0x0c, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x10, 0x02, 0x12,
+	0x08, 0x0a, 0x04, 0x50, 0x4c, 0x41, 0x59, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x55,
+	0x53, 0x45, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x10, 0x05, 0x12,
+	0x0c, 0x0a, 0x08, 0x54, 0x4f, 0x4f, 0x5f, 0x46, 0x41, 0x53, 0x54, 0x10, 0x06, 0x12, 0x0c, 0x0a,
+	0x08, 0x54, 0x4f, 0x4f, 0x5f, 0x53, 0x4c, 0x4f, 0x57, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x43,
+	0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x52, 0x41, 0x54, 0x45, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b,
+	0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x53, 0x45, 0x45, 0x4b, 0x10, 0x09, 0x12, 0x13, 0x0a,
+	0x0f, 0x43, 0x55, 0x52, 0x52, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44,
+	0x10, 0x0a, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x4f, 0x56, 0x49, 0x45, 0x53, 0x5f, 0x43, 0x48, 0x41,
+	0x4e, 0x47, 0x45, 0x44, 0x10, 0x0b, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x45, 0x4f, 0x50, 0x4c, 0x45,
+	0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x0c, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x59,
+	0x4e, 0x43, 0x5f, 0x4d, 0x4f, 0x56, 0x49, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10,
+	0x0d, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x0e,
+	0x12, 0x14, 0x0a, 0x10, 0x44, 0x52, 0x49, 0x46, 0x54, 0x5f, 0x43, 0x4f, 0x52, 0x52, 0x45, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x10, 0x0f, 0x12, 0x10, 0x0a, 0x0c, 0x48, 0x4f, 0x53, 0x54, 0x5f, 0x43,
+	0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x10, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x52, 0x45, 0x53,
+	0x45, 0x4e, 0x43, 0x45, 0x5f, 0x4a, 0x4f, 0x49, 0x4e, 0x10, 0x11, 0x12, 0x12, 0x0a, 0x0e, 0x50,
+	0x52, 0x45, 0x53, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x4c, 0x45, 0x41, 0x56, 0x45, 0x10, 0x12, 0x12,
+	0x13, 0x0a, 0x0f, 0x50, 0x52, 0x45, 0x53, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41,
+	0x54, 0x45, 0x10, 0x13, 0x12, 0x13, 0x0a, 0x0f, 0x42, 0x55, 0x46, 0x46, 0x45, 0x52, 0x49, 0x4e,
+	0x47, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x14, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x55, 0x46,
+	0x46, 0x45, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x45, 0x4e, 0x44, 0x10, 0x15, 0x12, 0x0a, 0x0a, 0x06,
+	0x52, 0x45, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x16, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x41, 0x4e, 0x4d,
+	0x41, 0x4b, 0x55, 0x10, 0x17, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x48, 0x49, 0x53, 0x50, 0x45, 0x52,
+	0x10, 0x18, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x52, 0x45, 0x54, 0x52, 0x41,
+	0x43, 0x54, 0x45, 0x44, 0x10, 0x19, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x4f, 0x4c, 0x4c, 0x5f, 0x53,
+	0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x1a, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x4f, 0x4c, 0x4c,
+	0x5f, 0x56, 0x4f, 0x54, 0x45, 0x10, 0x1b, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x4f, 0x4c, 0x4c, 0x5f,
+	0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x1c, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x4f, 0x4c,
+	0x4c, 0x5f, 0x45, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x1d, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x4e, 0x44,
+	0x45, 0x44, 0x10, 0x1e, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x45,
+	0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x1f, 0x12, 0x15, 0x0a, 0x11,
+	0x53, 0x43, 0x48, 0x45, 0x44, 0x55, 0x4c, 0x45, 0x53, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45,
+	0x44, 0x10, 0x20, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -617,7 +1652,7 @@ func file_proto_message_message_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_message_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_message_message_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_message_message_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_proto_message_message_proto_goTypes = []interface{}{
 	(ElementMessageType)(0),    // 0: proto.ElementMessageType
 	(*ChatResp)(nil),           // 1: proto.ChatResp
@@ -625,25 +1660,51 @@ var file_proto_message_message_proto_goTypes = []interface{}{
 	(*MovieStatus)(nil),        // 3: proto.MovieStatus
 	(*MovieStatusChanged)(nil), // 4: proto.MovieStatusChanged
 	(*CheckReq)(nil),           // 5: proto.CheckReq
-	(*ElementMessage)(nil),     // 6: proto.ElementMessage
+	(*TimeSyncReq)(nil),        // 6: proto.TimeSyncReq
+	(*TimeSyncResp)(nil),       // 7: proto.TimeSyncResp
+	(*DriftCorrection)(nil),    // 8: proto.DriftCorrection
+	(*Presence)(nil),           // 9: proto.Presence
+	(*Danmaku)(nil),            // 10: proto.Danmaku
+	(*Whisper)(nil),            // 11: proto.Whisper
+	(*Poll)(nil),               // 12: proto.Poll
+	(*PollVote)(nil),           // 13: proto.PollVote
+	(*Schedule)(nil),           // 14: proto.Schedule
+	(*ElementMessage)(nil),     // 15: proto.ElementMessage
 }
 var file_proto_message_message_proto_depIdxs = []int32{
 	2,  // 0: proto.ChatResp.sender:type_name -> proto.Sender
 	2,  // 1: proto.MovieStatusChanged.sender:type_name -> proto.Sender
 	3,  // 2: proto.MovieStatusChanged.status:type_name -> proto.MovieStatus
 	3,  // 3: proto.CheckReq.status:type_name -> proto.MovieStatus
-	0,  // 4: proto.ElementMessage.type:type_name -> proto.ElementMessageType
-	1,  // 5: proto.ElementMessage.chatResp:type_name -> proto.ChatResp
-	3,  // 6: proto.ElementMessage.changeMovieStatusReq:type_name -> proto.MovieStatus
-	4,  // 7: proto.ElementMessage.movieStatusChanged:type_name -> proto.MovieStatusChanged
-	5,  // 8: proto.ElementMessage.checkReq:type_name -> proto.CheckReq
-	2,  // 9: proto.ElementMessage.moviesChanged:type_name -> proto.Sender
-	2,  // 10: proto.ElementMessage.currentChanged:type_name -> proto.Sender
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	2,  // 4: proto.Presence.user:type_name -> proto.Sender
+	2,  // 5: proto.Danmaku.sender:type_name -> proto.Sender
+	2,  // 6: proto.Whisper.sender:type_name -> proto.Sender
+	2,  // 7: proto.Whisper.target:type_name -> proto.Sender
+	2,  // 8: proto.Poll.creator:type_name -> proto.Sender
+	2,  // 9: proto.Schedule.creator:type_name -> proto.Sender
+	0,  // 10: proto.ElementMessage.type:type_name -> proto.ElementMessageType
+	1,  // 11: proto.ElementMessage.chatResp:type_name -> proto.ChatResp
+	3,  // 12: proto.ElementMessage.changeMovieStatusReq:type_name -> proto.MovieStatus
+	4,  // 13: proto.ElementMessage.movieStatusChanged:type_name -> proto.MovieStatusChanged
+	5,  // 14: proto.ElementMessage.checkReq:type_name -> proto.CheckReq
+	2,  // 15: proto.ElementMessage.moviesChanged:type_name -> proto.Sender
+	2,  // 16: proto.ElementMessage.currentChanged:type_name -> proto.Sender
+	6,  // 17: proto.ElementMessage.timeSyncReq:type_name -> proto.TimeSyncReq
+	7,  // 18: proto.ElementMessage.timeSyncResp:type_name -> proto.TimeSyncResp
+	8,  // 19: proto.ElementMessage.driftCorrection:type_name -> proto.DriftCorrection
+	2,  // 20: proto.ElementMessage.hostChanged:type_name -> proto.Sender
+	9,  // 21: proto.ElementMessage.presence:type_name -> proto.Presence
+	10, // 22: proto.ElementMessage.danmaku:type_name -> proto.Danmaku
+	11, // 23: proto.ElementMessage.whisper:type_name -> proto.Whisper
+	12, // 24: proto.ElementMessage.poll:type_name -> proto.Poll
+	13, // 25: proto.ElementMessage.pollVote:type_name -> proto.PollVote
+	14, // 26: proto.ElementMessage.schedule:type_name -> proto.Schedule
+	2,  // 27: proto.ElementMessage.schedulesChanged:type_name -> proto.Sender
+	28, // [28:28] is the sub-list for method output_type
+	28, // [28:28] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_proto_message_message_proto_init() }
@@ -713,6 +1774,114 @@ func file_proto_message_message_proto_init() {
 			}
 		}
 		file_proto_message_message_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeSyncReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_message_message_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeSyncResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_message_message_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DriftCorrection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_message_message_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Presence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_message_message_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Danmaku); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_message_message_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Whisper); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_message_message_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Poll); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_message_message_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PollVote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_message_message_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Schedule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_message_message_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ElementMessage); i {
 			case 0:
 				return &v.state
@@ -731,7 +1900,7 @@ func file_proto_message_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_message_message_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
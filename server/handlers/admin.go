@@ -323,6 +323,8 @@ func genRoomMemberListResp(us []*dbModel.User, room *op.Room) []*model.RoomMembe
 			RoomID:           v.RoomMembers[0].RoomID,
 			Permissions:      v.RoomMembers[0].Permissions,
 			AdminPermissions: v.RoomMembers[0].AdminPermissions,
+			MutedUntil:       v.RoomMembers[0].MutedUntil,
+			Roles:            genRoomRoleIDs(room.MemberRoles(v.ID)),
 		}
 	}
 	return resp
@@ -1104,3 +1106,11 @@ func SendTestEmail(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+func AdminWebSocketStats(ctx *gin.Context) {
+	dropped, evicted := op.SlowConsumerStats()
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(&model.WebSocketStatsResp{
+		DroppedMessages: dropped,
+		EvictedClients:  evicted,
+	}))
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/op"
+	"github.com/synctv-org/synctv/server/model"
+	"github.com/synctv-org/synctv/utils"
+	"gorm.io/gorm"
+)
+
+// RoomAuditLogs lists the audit log of the room, newest first,
+// filtered by action, actorId, targetId, and since and until in unix milliseconds.
+func RoomAuditLogs(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	page, pageSize, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		log.Errorf("get room audit logs failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	scopes := []func(db *gorm.DB) *gorm.DB{}
+
+	if action := dbModel.AuditAction(ctx.Query("action")); action != "" {
+		if !utils.In(dbModel.AuditActions, action) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorStringResp("unknown action"))
+			return
+		}
+		scopes = append(scopes, db.WhereEqual("action", action))
+	}
+	if actorID := ctx.Query("actorId"); actorID != "" {
+		scopes = append(scopes, db.WhereEqual("actor_id", actorID))
+	}
+	if targetID := ctx.Query("targetId"); targetID != "" {
+		scopes = append(scopes, db.WhereEqual("target_id", targetID))
+	}
+	for _, q := range []string{"since", "until"} {
+		v := ctx.Query(q)
+		if v == "" {
+			continue
+		}
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorStringResp(q+" must be unix milliseconds"))
+			return
+		}
+		if q == "since" {
+			scopes = append(scopes, db.WhereCreatedAtAfter(time.UnixMilli(ms)))
+		} else {
+			scopes = append(scopes, db.WhereCreatedAtBefore(time.UnixMilli(ms)))
+		}
+	}
+
+	list, total, err := user.RoomAuditLogs(room, page, pageSize, scopes...)
+	if err != nil {
+		log.Errorf("get room audit logs failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	resp := make([]*model.AuditLogResp, len(list))
+	for i, l := range list {
+		resp[i] = &model.AuditLogResp{
+			ID:        l.ID,
+			Action:    l.Action,
+			ActorID:   l.ActorID,
+			ActorName: op.GetUserName(l.ActorID),
+			TargetID:  l.TargetID,
+			OldValue:  l.OldValue,
+			NewValue:  l.NewValue,
+			CreatedAt: l.CreatedAt.UnixMilli(),
+		}
+		// the other targets are movies
+		if strings.HasPrefix(string(l.Action), "member_") || l.Action == dbModel.AuditActionOwnerTransfer {
+			resp[i].TargetName = op.GetUserName(l.TargetID)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(gin.H{
+		"total": total,
+		"list":  resp,
+	}))
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	"github.com/synctv-org/synctv/internal/op"
+	"github.com/synctv-org/synctv/server/model"
+	"github.com/synctv-org/synctv/utils"
+)
+
+func ChatHistory(ctx *gin.Context) {
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	_, max, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		log.Errorf("get chat history failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	var before uint64
+	if b := ctx.Query("before"); b != "" {
+		before, err = strconv.ParseUint(b, 10, 64)
+		if err != nil {
+			log.Errorf("get chat history failed: %v", err)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(errors.New("before must be a number")))
+			return
+		}
+	}
+
+	msgs, err := db.GetChatMessagesBefore(room.ID, before, max, db.WhereChatVisibleTo(user.ID))
+	if err != nil {
+		log.Errorf("get chat history failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	list := make([]*model.ChatMessageResp, len(msgs))
+	for i, m := range msgs {
+		list[i] = &model.ChatMessageResp{
+			ID:       m.ID,
+			SenderID: m.SenderID,
+			Username: op.GetUserName(m.SenderID),
+			Message:  m.Message,
+			Time:     m.CreatedAt.UnixMilli(),
+		}
+		if m.ReceiverID != "" {
+			list[i].ReceiverID = m.ReceiverID
+			list[i].ReceiverName = op.GetUserName(m.ReceiverID)
+		}
+	}
+
+	var next uint64
+	if len(msgs) == max {
+		next = msgs[len(msgs)-1].ID
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(gin.H{
+		"list": list,
+		"next": next,
+	}))
+}
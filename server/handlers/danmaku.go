@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/op"
+	"github.com/synctv-org/synctv/server/model"
+)
+
+const (
+	MaxDanmakuLength = 256
+	maxDanmakuWindow = 3000
+)
+
+func MovieDanmaku(ctx *gin.Context) {
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	movie, err := room.GetMovieByID(ctx.Query("id"))
+	if err != nil {
+		log.Errorf("get danmaku failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	start, end := 0.0, math.MaxFloat64
+	if s := ctx.Query("start"); s != "" {
+		start, err = strconv.ParseFloat(s, 64)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(errors.New("start must be a number")))
+			return
+		}
+	}
+	if e := ctx.Query("end"); e != "" {
+		end, err = strconv.ParseFloat(e, 64)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(errors.New("end must be a number")))
+			return
+		}
+	}
+
+	list, err := db.GetDanmakuInRange(movie.Movie.ID, start, end, maxDanmakuWindow)
+	if err != nil {
+		log.Errorf("get danmaku failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	resp := make([]*model.DanmakuResp, len(list))
+	for i, d := range list {
+		resp[i] = &model.DanmakuResp{
+			ID:       d.ID,
+			SenderID: d.SenderID,
+			Time:     d.Time,
+			Content:  d.Content,
+			Color:    d.Color,
+			Mode:     uint32(d.Mode),
+		}
+		if d.SenderID != "" {
+			resp[i].Username = op.GetUserName(d.SenderID)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(resp))
+}
+
+func ImportMovieDanmaku(ctx *gin.Context) {
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	req := model.IdReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("import danmaku error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	count, err := user.ImportRoomMovieDanmaku(ctx, room, req.Id)
+	if err != nil {
+		log.Errorf("import danmaku error: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(&model.ImportDanmakuResp{
+		Count: count,
+	}))
+}
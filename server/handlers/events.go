@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/op"
+	pb "github.com/synctv-org/synctv/proto/message"
+	"github.com/synctv-org/synctv/server/model"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// the broadcasts streamed to the read-only event clients
+var roomEventTypes = map[pb.ElementMessageType]bool{
+	pb.ElementMessageType_CURRENT_CHANGED: true,
+	pb.ElementMessageType_MOVIES_CHANGED:  true,
+	pb.ElementMessageType_PLAY:            true,
+	pb.ElementMessageType_PAUSE:           true,
+	pb.ElementMessageType_CHANGE_RATE:     true,
+	pb.ElementMessageType_CHANGE_SEEK:     true,
+	pb.ElementMessageType_CHAT_MESSAGE:    true,
+	pb.ElementMessageType_CHAT_RETRACTED:  true,
+	pb.ElementMessageType_PEOPLE_CHANGED:  true,
+	// the missed messages are no longer buffered, refetch the room
+	pb.ElementMessageType_RESYNC: true,
+}
+
+// RoomEvents streams the broadcasts of the room as Server-Sent Events in json,
+// the event id is the sequence of the message, so a reconnecting EventSource resumes by Last-Event-ID.
+func RoomEvents(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	conf, err := slowConsumerConf(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+	var cli *op.Client
+	if id := ctx.GetHeader("Last-Event-ID"); id != "" {
+		seq, perr := strconv.ParseUint(id, 10, 64)
+		if perr != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorStringResp("Last-Event-ID must be a number"))
+			return
+		}
+		cli, _, err = room.ResumeClient(user, nil, seq, conf...)
+	} else {
+		cli, err = room.NewClient(user, nil, conf...)
+	}
+	if err != nil {
+		log.Errorf("sse: register client error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+	log.Info("sse: connected")
+	defer func() {
+		_ = room.UnregisterClient(cli)
+		cli.Close()
+		log.Info("sse: disconnected")
+	}()
+
+	h := ctx.Writer.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	_ = cli.Send(&pb.ElementMessage{
+		Type:          pb.ElementMessageType_PEOPLE_CHANGED,
+		PeopleChanged: room.PeopleNum(),
+	})
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case msg, ok := <-cli.GetReadChan():
+			if !ok {
+				return
+			}
+			if err := writeRoomEvent(ctx.Writer, msg); err != nil {
+				log.Errorf("sse: write event error: %v", err)
+				return
+			}
+			ctx.Writer.Flush()
+		}
+	}
+}
+
+func writeRoomEvent(w gin.ResponseWriter, msg op.Message) error {
+	em, ok := msg.(*pb.ElementMessage)
+	if !ok {
+		// keeps the connection alive through proxies
+		_, err := fmt.Fprint(w, ": ping\n\n")
+		return err
+	}
+	if !roomEventTypes[em.Type] {
+		return nil
+	}
+	b, err := protojson.Marshal(em)
+	if err != nil {
+		return err
+	}
+	if em.Seq != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", em.Seq); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", em.Type.String(), b)
+	return err
+}
@@ -109,6 +109,8 @@ func initAdmin(admin *gin.RouterGroup, root *gin.RouterGroup) {
 
 		admin.POST("/vendors/disable", AdminDisableVendorBackends)
 
+		admin.GET("/ws/stats", AdminWebSocketStats)
+
 		{
 			user := admin.Group("/user")
 
@@ -138,6 +140,8 @@ func initAdmin(admin *gin.RouterGroup, root *gin.RouterGroup) {
 
 			room.POST("/password", AdminRoomPassword)
 
+			room.POST("/transfer", AdminTransferRoom)
+
 			// 查找房间
 			room.GET("/list", Rooms)
 
@@ -171,14 +175,52 @@ func initRoom(room *gin.RouterGroup, needAuthUser *gin.RouterGroup, needAuthRoom
 
 	needAuthUser.POST("/create", CreateRoom)
 
+	needAuthUser.POST("/import", ImportRoom)
+
+	needAuthUser.GET("/templates", RoomTemplates)
+
+	needAuthUser.POST("/templates", CreateRoomTemplate)
+
+	needAuthUser.POST("/templates/delete", DeleteRoomTemplate)
+
 	needAuthUser.POST("/login", LoginRoom)
 
+	needAuthUser.POST("/invite/redeem", RedeemRoomInvite)
+
+	needAuthUser.GET("/transfers", IncomingRoomTransfers)
+
+	needAuthUser.POST("/transfer/accept", AcceptRoomTransfer)
+
+	needAuthUser.POST("/transfer/decline", DeclineRoomTransfer)
+
 	needAuthRoom.GET("/me", RoomMe)
 
 	needAuthRoom.GET("/settings", RoomPiblicSettings)
 
+	needAuthRoom.GET("/roles", RoomRoles)
+
 	needAuthRoom.GET("/members", RoomMembers)
 
+	needAuthRoom.GET("/presence", RoomPresence)
+
+	needAuthRoom.GET("/events", RoomEvents)
+
+	needAuthRoom.GET("/chat/history", ChatHistory)
+
+	needAuthRoom.GET("/host", RoomHost)
+
+	needAuthRoom.POST("/host", RoomSetHost)
+
+	needAuthRoom.GET("/poll", RoomPoll)
+
+	needAuthRoom.POST("/poll", StartRoomPoll)
+
+	needAuthRoom.POST("/poll/cancel", CancelRoomPoll)
+
+	needAuthRoom.GET("/schedules", RoomSchedules)
+
+	needAuthRoom.GET("/schedules.ics", RoomSchedulesICS)
+
 	{
 		needAuthRoomAdmin := needAuthRoom.Group("/admin", middlewares.AuthRoomAdminMiddleware)
 		needAuthRoomCreator := needAuthRoom.Group("/admin", middlewares.AuthRoomCreatorMiddleware)
@@ -193,12 +235,50 @@ func initRoom(room *gin.RouterGroup, needAuthUser *gin.RouterGroup, needAuthRoom
 
 		needAuthRoomAdmin.GET("/members", RoomAdminMembers)
 
+		needAuthRoomAdmin.GET("/sync", RoomAdminClientsSync)
+
 		needAuthRoomAdmin.POST("/members/approve", RoomAdminApproveMember)
 
 		needAuthRoomAdmin.POST("/members/ban", RoomAdminBanMember)
 
 		needAuthRoomAdmin.POST("/members/unban", RoomAdminUnbanMember)
 
+		needAuthRoomAdmin.POST("/members/mute", RoomAdminMuteMember)
+
+		needAuthRoomAdmin.POST("/members/unmute", RoomAdminUnmuteMember)
+
+		needAuthRoomAdmin.POST("/chat/delete", RoomAdminDeleteChatMessage)
+
+		needAuthRoomAdmin.GET("/chat/moderation", RoomAdminChatModeration)
+
+		needAuthRoomAdmin.POST("/chat/moderation", RoomAdminSetChatModeration)
+
+		needAuthRoomAdmin.POST("/schedule", ScheduleRoomMovie)
+
+		needAuthRoomAdmin.POST("/schedule/cancel", CancelRoomSchedule)
+
+		needAuthRoomAdmin.GET("/invites", RoomInvites)
+
+		needAuthRoomAdmin.POST("/invites", CreateRoomInvite)
+
+		needAuthRoomAdmin.POST("/invites/revoke", RevokeRoomInvite)
+
+		needAuthRoomAdmin.GET("/export", ExportRoom)
+
+		needAuthRoomAdmin.POST("/template", SaveRoomAsTemplate)
+
+		needAuthRoomAdmin.POST("/clone", CloneRoom)
+
+		needAuthRoomAdmin.GET("/audit", RoomAuditLogs)
+
+		needAuthRoomAdmin.GET("/webhooks", RoomWebhooks)
+
+		needAuthRoomAdmin.POST("/webhooks", AddRoomWebhook)
+
+		needAuthRoomAdmin.POST("/webhooks/delete", DeleteRoomWebhook)
+
+		needAuthRoomAdmin.GET("/webhooks/deliveries", RoomWebhookDeliveries)
+
 		needAuthRoomCreator.POST("/members/member", RoomSetMember)
 
 		needAuthRoomCreator.POST("/members/member/permissions", RoomSetMemberPermissions)
@@ -206,6 +286,20 @@ func initRoom(room *gin.RouterGroup, needAuthUser *gin.RouterGroup, needAuthRoom
 		needAuthRoomCreator.POST("/members/admin", RoomSetAdmin)
 
 		needAuthRoomCreator.POST("/members/admin/permissions", RoomSetAdminPermissions)
+
+		needAuthRoomCreator.GET("/transfer", RoomTransfer)
+
+		needAuthRoomCreator.POST("/transfer", OfferRoomTransfer)
+
+		needAuthRoomCreator.POST("/transfer/cancel", CancelRoomTransfer)
+
+		needAuthRoomCreator.POST("/roles", CreateRoomRole)
+
+		needAuthRoomCreator.POST("/roles/update", UpdateRoomRole)
+
+		needAuthRoomCreator.POST("/roles/delete", DeleteRoomRole)
+
+		needAuthRoomCreator.POST("/members/roles", RoomSetMemberRoles)
 	}
 }
 
@@ -230,6 +324,10 @@ func initMovie(movie *gin.RouterGroup, needAuthMovie *gin.RouterGroup) {
 
 	needAuthMovie.POST("/clear", ClearMovies)
 
+	needAuthMovie.GET("/danmaku", MovieDanmaku)
+
+	needAuthMovie.POST("/danmaku/import", ImportMovieDanmaku)
+
 	movie.HEAD("/proxy/:roomId/:movieId", ProxyMovie)
 
 	movie.GET("/proxy/:roomId/:movieId", ProxyMovie)
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/op"
+	"github.com/synctv-org/synctv/server/middlewares"
+	"github.com/synctv-org/synctv/server/model"
+)
+
+func genInviteResp(i *dbModel.RoomInvite) *model.InviteResp {
+	return &model.InviteResp{
+		ID:          i.ID,
+		Token:       i.Token,
+		Permissions: i.Permissions,
+		MaxUses:     i.MaxUses,
+		Uses:        i.Uses,
+		ExpiresAt:   i.ExpiresAt.UnixMilli(),
+		CreatedAt:   i.CreatedAt.UnixMilli(),
+		CreatorID:   i.CreatorID,
+		CreatorName: op.GetUserName(i.CreatorID),
+		Usable:      i.Usable(),
+	}
+}
+
+func RoomInvites(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	list, err := user.RoomInvites(room)
+	if err != nil {
+		log.Errorf("get room invites failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	resp := make([]*model.InviteResp, len(list))
+	for i, v := range list {
+		resp[i] = genInviteResp(v)
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(resp))
+}
+
+func CreateRoomInvite(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.CreateInviteReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode create invite req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	i, err := user.CreateRoomInvite(room, req.Permissions, req.MaxUses, time.UnixMilli(req.ExpiresAt))
+	if err != nil {
+		log.Errorf("create room invite failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(genInviteResp(i)))
+}
+
+func RevokeRoomInvite(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.IdReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode revoke invite req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	if err := user.RevokeRoomInvite(room, req.Id); err != nil {
+		log.Errorf("revoke room invite failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RedeemRoomInvite joins the room of the invite and logs in to it like LoginRoom
+func RedeemRoomInvite(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.RedeemInviteReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode redeem invite req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	roomE, err := user.RedeemRoomInvite(req.Token)
+	if err != nil {
+		log.Errorf("redeem room invite failed: %v", err)
+		var notFound db.ErrNotFound
+		switch {
+		case errors.As(err, &notFound):
+			ctx.AbortWithStatusJSON(http.StatusNotFound, model.NewApiErrorResp(err))
+		case errors.Is(err, op.ErrRoomBanned),
+			errors.Is(err, op.ErrRoomPending),
+			errors.Is(err, op.ErrInviteMemberBanned),
+			errors.Is(err, op.ErrInviteGuest):
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+		default:
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		}
+		return
+	}
+	room := roomE.Value()
+
+	token, err := middlewares.NewAuthRoomToken(user, room)
+	if err != nil {
+		log.Errorf("redeem room invite failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(gin.H{
+		"roomId": room.ID,
+		"token":  token,
+	}))
+}
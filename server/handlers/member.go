@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -363,3 +364,61 @@ func RoomSetAdminPermissions(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+func RoomHost(ctx *gin.Context) {
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+
+	hostID := room.HostID()
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(&model.RoomHostResp{
+		UserID:   hostID,
+		Username: op.GetUserName(hostID),
+	}))
+}
+
+func RoomSetHost(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.RoomSetHostReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode room set host req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	if err := user.SetRoomHost(room, req.ID); err != nil {
+		log.Errorf("set room host failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func RoomPresence(ctx *gin.Context) {
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+
+	presences := room.Presences()
+	resp := make([]*model.RoomPresenceResp, len(presences))
+	for i, p := range presences {
+		resp[i] = &model.RoomPresenceResp{
+			UserID:   p.UserID,
+			Username: p.Username,
+			Devices:  p.Devices,
+		}
+		if p.Checked {
+			resp[i].Playing = p.Status.Playing
+			resp[i].Seek = p.Status.Seek
+			resp[i].Buffering = p.Status.Buffering
+			resp[i].Drift = p.Status.Drift
+			resp[i].CheckedAt = p.Status.CheckedAt.UnixMilli()
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(resp))
+}
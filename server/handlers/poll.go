@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/op"
+	"github.com/synctv-org/synctv/server/model"
+)
+
+func genPollResp(p *op.Poll) *model.PollResp {
+	pp := p.Proto()
+	return &model.PollResp{
+		ID:          pp.Id,
+		Kind:        pp.Kind,
+		Question:    pp.Question,
+		Options:     pp.Options,
+		Votes:       pp.Votes,
+		Voters:      pp.Voters,
+		Quorum:      pp.Quorum,
+		ExpiresAt:   pp.ExpiresAt,
+		CreatorID:   pp.Creator.Userid,
+		CreatorName: pp.Creator.Username,
+		MovieID:     pp.MovieId,
+		Result:      pp.Result,
+		Ended:       pp.Ended,
+	}
+}
+
+func RoomPoll(ctx *gin.Context) {
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+
+	p := room.Poll()
+	if p == nil {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, model.NewApiErrorResp(op.ErrNoActivePoll))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(genPollResp(p)))
+}
+
+func StartRoomPoll(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.StartPollReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode start poll req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	p, err := user.StartRoomPoll(room, req.Kind, op.PollConf{
+		Question: req.Question,
+		Options:  req.Options,
+		Quorum:   req.Quorum,
+		Timeout:  time.Duration(req.Timeout) * time.Second,
+	})
+	if err != nil {
+		log.Errorf("start poll failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(genPollResp(p)))
+}
+
+func CancelRoomPoll(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	if err := user.CancelRoomPoll(room); err != nil {
+		log.Errorf("cancel poll failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
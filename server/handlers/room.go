@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"slices"
 	"time"
@@ -46,14 +48,23 @@ func RoomMe(ctx *gin.Context) {
 		RoomID:           room.ID,
 		JoinAt:           rur.CreatedAt.UnixMilli(),
 		Role:             rur.Role,
-		Permissions:      rur.Permissions,
-		AdminPermissions: rur.AdminPermissions,
+		Permissions:      room.MemberPermissions(rur),
+		AdminPermissions: room.MemberAdminPermissions(rur),
+		Roles:            genRoomRoleIDs(room.MemberRoles(user.ID)),
 	}))
 }
 
 func RoomPiblicSettings(ctx *gin.Context) {
 	room := ctx.MustGet("room").(*op.RoomEntry).Value()
-	ctx.JSON(http.StatusOK, model.NewApiDataResp(room.Settings))
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+
+	rs := *room.Settings
+	// the blocklist is hidden from the members it filters
+	if !user.HasRoomAdminPermission(room, dbModel.PermissionSetRoomSettings) &&
+		!user.HasRoomAdminPermission(room, dbModel.PermissionSetChatModeration) {
+		rs.ChatBlocklist = ""
+	}
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(&rs))
 }
 
 func CreateRoom(ctx *gin.Context) {
@@ -73,7 +84,20 @@ func CreateRoom(ctx *gin.Context) {
 		return
 	}
 
-	room, err := user.CreateRoom(req.RoomName, req.Password, db.WithSettingHidden(req.Settings.Hidden))
+	var (
+		room *op.RoomEntry
+		err  error
+	)
+	if req.TemplateID != "" {
+		var conf []db.CreateRoomConfig
+		// the template decides unless the room is asked to be hidden
+		if req.Settings.Hidden {
+			conf = append(conf, db.WithSettingHidden(true))
+		}
+		room, err = user.CreateRoomFromTemplate(req.TemplateID, req.RoomName, req.Password, conf...)
+	} else {
+		room, err = user.CreateRoom(req.RoomName, req.Password, db.WithSettingHidden(req.Settings.Hidden))
+	}
 	if err != nil {
 		log.Errorf("create room failed: %v", err)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
@@ -466,3 +490,29 @@ func SetRoomSetting(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+func RoomAdminClientsSync(ctx *gin.Context) {
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+
+	list := make([]*model.RoomClientSyncResp, 0)
+	room.RangeClients(func(c *op.Client) bool {
+		resp := &model.RoomClientSyncResp{
+			UserID:   c.User().ID,
+			Username: c.User().Username,
+			RTT:      c.RTT().Milliseconds(),
+			Dropped:  c.Dropped(),
+		}
+		drift, checkedAt := c.Drift()
+		resp.Drift = drift
+		if !checkedAt.IsZero() {
+			resp.CheckedAt = checkedAt.UnixMilli()
+		}
+		list = append(list, resp)
+		return true
+	})
+	slices.SortStableFunc(list, func(a, b *model.RoomClientSyncResp) int {
+		return cmp.Compare(math.Abs(b.Drift), math.Abs(a.Drift))
+	})
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(list))
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/op"
+	"github.com/synctv-org/synctv/server/model"
+)
+
+func genRoomRoleResp(r *dbModel.RoomRole) *model.RoomRoleResp {
+	return &model.RoomRoleResp{
+		ID:               r.ID,
+		Name:             r.Name,
+		Permissions:      r.Permissions,
+		AdminPermissions: r.AdminPermissions,
+		CreatedAt:        r.CreatedAt.UnixMilli(),
+		UpdatedAt:        r.UpdatedAt.UnixMilli(),
+	}
+}
+
+func genRoomRoleIDs(roles []*dbModel.RoomRole) []string {
+	ids := make([]string, len(roles))
+	for i, r := range roles {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func roomRoleErrorStatus(err error) int {
+	var notFound db.ErrNotFound
+	switch {
+	case errors.As(err, &notFound):
+		return http.StatusNotFound
+	case errors.Is(err, dbModel.ErrNoPermission):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func RoomRoles(ctx *gin.Context) {
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+
+	list := room.Roles()
+	resp := make([]*model.RoomRoleResp, len(list))
+	for i, v := range list {
+		resp[i] = genRoomRoleResp(v)
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(resp))
+}
+
+func CreateRoomRole(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.RoomRoleReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode create room role req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	r, err := user.CreateRoomRole(room, req.Name, req.Permissions, req.AdminPermissions)
+	if err != nil {
+		log.Errorf("create room role failed: %v", err)
+		ctx.AbortWithStatusJSON(roomRoleErrorStatus(err), model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(genRoomRoleResp(r)))
+}
+
+func UpdateRoomRole(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.UpdateRoomRoleReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode update room role req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	r, err := user.UpdateRoomRole(room, req.ID, req.Name, req.Permissions, req.AdminPermissions)
+	if err != nil {
+		log.Errorf("update room role failed: %v", err)
+		ctx.AbortWithStatusJSON(roomRoleErrorStatus(err), model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(genRoomRoleResp(r)))
+}
+
+func DeleteRoomRole(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.IdReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode delete room role req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	if err := user.DeleteRoomRole(room, req.Id); err != nil {
+		log.Errorf("delete room role failed: %v", err)
+		ctx.AbortWithStatusJSON(roomRoleErrorStatus(err), model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func RoomSetMemberRoles(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.SetMemberRolesReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode set member roles req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	if err := user.SetRoomMemberRoles(room, req.ID, req.Roles); err != nil {
+		log.Errorf("set member roles failed: %v", err)
+		ctx.AbortWithStatusJSON(roomRoleErrorStatus(err), model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
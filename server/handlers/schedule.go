@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/op"
+	"github.com/synctv-org/synctv/server/model"
+)
+
+func genScheduleResp(room *op.Room, s *dbModel.RoomSchedule) *model.ScheduleResp {
+	resp := &model.ScheduleResp{
+		ID:          s.ID,
+		MovieID:     s.MovieID,
+		Title:       s.Title,
+		StartAt:     s.StartAt.UnixMilli(),
+		CreatorID:   s.CreatorID,
+		CreatorName: op.GetUserName(s.CreatorID),
+	}
+	if m, err := room.GetMovieByID(s.MovieID); err == nil {
+		resp.MovieName = m.Movie.Base.Name
+	}
+	return resp
+}
+
+func RoomSchedules(ctx *gin.Context) {
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	list, err := room.Schedules()
+	if err != nil {
+		log.Errorf("get room schedules failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	resp := make([]*model.ScheduleResp, len(list))
+	for i, s := range list {
+		resp[i] = genScheduleResp(room, s)
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(resp))
+}
+
+func ScheduleRoomMovie(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.ScheduleMovieReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode schedule movie req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	s, err := user.ScheduleRoomMovie(room, req.MovieID, req.Title, time.UnixMilli(req.StartAt))
+	if err != nil {
+		log.Errorf("schedule movie failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(genScheduleResp(room, s)))
+}
+
+func CancelRoomSchedule(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.IdReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode cancel schedule req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	if err := user.CancelRoomSchedule(room, req.Id); err != nil {
+		log.Errorf("cancel schedule failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RoomSchedulesICS serves the upcoming schedules as an iCalendar feed,
+// calendar apps can authorize with the token query parameter.
+func RoomSchedulesICS(ctx *gin.Context) {
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	list, err := room.Schedules()
+	if err != nil {
+		log.Errorf("get room schedules failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	b := &strings.Builder{}
+	b.WriteString("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//SyncTV//Schedules//EN\r\n")
+	fmt.Fprintf(b, "X-WR-CALNAME:%s\r\n", icsEscape(room.Name))
+	for _, s := range list {
+		summary := s.Title
+		var duration float64
+		if m, err := room.GetMovieByID(s.MovieID); err == nil {
+			if summary == "" {
+				summary = m.Movie.Base.Name
+			}
+			duration = m.Movie.Base.Duration
+		}
+		b.WriteString("BEGIN:VEVENT\r\n")
+		fmt.Fprintf(b, "UID:%s@synctv\r\n", s.ID)
+		fmt.Fprintf(b, "DTSTAMP:%s\r\n", icsTime(s.CreatedAt))
+		fmt.Fprintf(b, "DTSTART:%s\r\n", icsTime(s.StartAt))
+		if duration > 0 {
+			fmt.Fprintf(b, "DTEND:%s\r\n", icsTime(s.StartAt.Add(time.Duration(duration*float64(time.Second)))))
+		}
+		fmt.Fprintf(b, "SUMMARY:%s\r\n", icsEscape(summary))
+		b.WriteString("END:VEVENT\r\n")
+	}
+	b.WriteString("END:VCALENDAR\r\n")
+
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(b.String()))
+}
+
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icsEscape(s string) string {
+	return icsEscaper.Replace(s)
+}
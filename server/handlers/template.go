@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/op"
+	"github.com/synctv-org/synctv/internal/settings"
+	"github.com/synctv-org/synctv/server/middlewares"
+	"github.com/synctv-org/synctv/server/model"
+)
+
+func genTemplateResp(t *dbModel.RoomTemplate) *model.TemplateResp {
+	resp := &model.TemplateResp{
+		ID:          t.ID,
+		Name:        t.Name,
+		Settings:    t.Settings,
+		Permissions: t.Permissions,
+		Movies:      make([]*dbModel.BaseMovie, len(t.Movies)),
+		CreatedAt:   t.CreatedAt.UnixMilli(),
+		UpdatedAt:   t.UpdatedAt.UnixMilli(),
+	}
+	for i, m := range t.Movies {
+		resp.Movies[i] = &m.Base
+	}
+	return resp
+}
+
+func RoomTemplates(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	list, err := user.RoomTemplates()
+	if err != nil {
+		log.Errorf("get room templates failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	resp := make([]*model.TemplateResp, len(list))
+	for i, t := range list {
+		resp[i] = genTemplateResp(t)
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(resp))
+}
+
+func CreateRoomTemplate(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.CreateTemplateReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode create template req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	movies := make([]*dbModel.BaseMovie, len(req.Movies))
+	for i, m := range req.Movies {
+		movies[i] = (*dbModel.BaseMovie)(m)
+	}
+	t, err := user.CreateRoomTemplate(req.Name, req.Settings, req.Permissions, movies)
+	if err != nil {
+		log.Errorf("create room template failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(genTemplateResp(t)))
+}
+
+func DeleteRoomTemplate(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.IdReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode delete template req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	if err := user.DeleteRoomTemplate(req.Id); err != nil {
+		log.Errorf("delete room template failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func SaveRoomAsTemplate(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.SaveRoomTemplateReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode save room template req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	t, err := user.SaveRoomAsTemplate(room, req.Name, req.WithMovies)
+	if err != nil {
+		log.Errorf("save room as template failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(genTemplateResp(t)))
+}
+
+func CloneRoom(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	if settings.DisableCreateRoom.Get() && !user.IsAdmin() {
+		log.Error("create room is disabled")
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorStringResp("create room is disabled"))
+		return
+	}
+
+	var req model.CloneRoomReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("clone room failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	newRoom, err := user.CloneRoom(room, req.RoomName, req.Password)
+	if err != nil {
+		log.Errorf("clone room failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	token, err := middlewares.NewAuthRoomToken(user, newRoom.Value())
+	if err != nil {
+		log.Errorf("clone room failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.NewApiDataResp(gin.H{
+		"roomId": newRoom.Value().ID,
+		"token":  token,
+	}))
+}
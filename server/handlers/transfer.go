@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/synctv-org/synctv/internal/db"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/op"
+	"github.com/synctv-org/synctv/server/model"
+)
+
+func genRoomTransferResp(t *dbModel.RoomTransfer, roomName string) *model.RoomTransferResp {
+	return &model.RoomTransferResp{
+		RoomID:    t.RoomID,
+		RoomName:  roomName,
+		FromID:    t.FromID,
+		FromName:  op.GetUserName(t.FromID),
+		ToID:      t.ToID,
+		ToName:    op.GetUserName(t.ToID),
+		CreatedAt: t.CreatedAt.UnixMilli(),
+		ExpiresAt: t.ExpiresAt.UnixMilli(),
+	}
+}
+
+func transferErrorStatus(err error) int {
+	var notFound db.ErrNotFound
+	switch {
+	case errors.As(err, &notFound):
+		return http.StatusNotFound
+	case errors.Is(err, dbModel.ErrNoPermission):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func RoomTransfer(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	t, err := user.RoomTransfer(room)
+	if err != nil {
+		log.Errorf("get room transfer failed: %v", err)
+		ctx.AbortWithStatusJSON(transferErrorStatus(err), model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(genRoomTransferResp(t, room.Name)))
+}
+
+func OfferRoomTransfer(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.OfferRoomTransferReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode offer room transfer req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	t, err := user.OfferRoomTransfer(room, req.ID)
+	if err != nil {
+		log.Errorf("offer room transfer failed: %v", err)
+		ctx.AbortWithStatusJSON(transferErrorStatus(err), model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(genRoomTransferResp(t, room.Name)))
+}
+
+func CancelRoomTransfer(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	if err := user.CancelRoomTransfer(room); err != nil {
+		log.Errorf("cancel room transfer failed: %v", err)
+		ctx.AbortWithStatusJSON(transferErrorStatus(err), model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func IncomingRoomTransfers(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	list, err := user.IncomingRoomTransfers()
+	if err != nil {
+		log.Errorf("get incoming room transfers failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	resp := make([]*model.RoomTransferResp, 0, len(list))
+	for _, t := range list {
+		r, err := db.GetRoomByID(t.RoomID)
+		if err != nil {
+			log.Errorf("get room of transfer failed: %v", err)
+			continue
+		}
+		resp = append(resp, genRoomTransferResp(t, r.Name))
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(resp))
+}
+
+func AcceptRoomTransfer(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.RoomIDReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode accept room transfer req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	if err := user.AcceptRoomTransfer(req.Id); err != nil {
+		log.Errorf("accept room transfer failed: %v", err)
+		ctx.AbortWithStatusJSON(transferErrorStatus(err), model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func DeclineRoomTransfer(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.RoomIDReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode decline room transfer req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	if err := user.DeclineRoomTransfer(req.Id); err != nil {
+		log.Errorf("decline room transfer failed: %v", err)
+		ctx.AbortWithStatusJSON(transferErrorStatus(err), model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func AdminTransferRoom(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.AdminTransferRoomReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode admin transfer room req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	if err := user.TransferRoomByID(req.RoomID, req.UserID); err != nil {
+		log.Errorf("admin transfer room failed: %v", err)
+		ctx.AbortWithStatusJSON(transferErrorStatus(err), model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+	"github.com/synctv-org/synctv/internal/op"
+	"github.com/synctv-org/synctv/server/model"
+	"github.com/synctv-org/synctv/utils"
+)
+
+func genWebhookResp(w *dbModel.RoomWebhook) *model.WebhookResp {
+	return &model.WebhookResp{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    w.Events,
+		CreatorID: w.CreatorID,
+		CreatedAt: w.CreatedAt.UnixMilli(),
+	}
+}
+
+func RoomWebhooks(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	list, err := user.RoomWebhooks(room)
+	if err != nil {
+		log.Errorf("get room webhooks failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewApiErrorResp(err))
+		return
+	}
+
+	resp := make([]*model.WebhookResp, len(list))
+	for i, w := range list {
+		resp[i] = genWebhookResp(w)
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(resp))
+}
+
+func AddRoomWebhook(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.AddWebhookReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode add webhook req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	w, err := user.AddRoomWebhook(room, req.URL, req.Events)
+	if err != nil {
+		log.Errorf("add room webhook failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	resp := genWebhookResp(w)
+	resp.Secret = w.Secret
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(resp))
+}
+
+func DeleteRoomWebhook(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	var req model.IdReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("decode delete webhook req failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	if err := user.DeleteRoomWebhook(room, req.Id); err != nil {
+		log.Errorf("delete room webhook failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func RoomWebhookDeliveries(ctx *gin.Context) {
+	user := ctx.MustGet("user").(*op.UserEntry).Value()
+	room := ctx.MustGet("room").(*op.RoomEntry).Value()
+	log := ctx.MustGet("log").(*logrus.Entry)
+
+	page, max, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		log.Errorf("get webhook deliveries failed: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	list, total, err := user.RoomWebhookDeliveries(room, ctx.Query("id"), page, max)
+	if err != nil {
+		log.Errorf("get webhook deliveries failed: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+		return
+	}
+
+	resp := make([]*model.WebhookDeliveryResp, len(list))
+	for i, d := range list {
+		resp[i] = &model.WebhookDeliveryResp{
+			ID:         d.ID,
+			Event:      d.Event,
+			Payload:    d.Payload,
+			Status:     d.Status,
+			Attempts:   d.Attempts,
+			StatusCode: d.StatusCode,
+			Error:      d.Error,
+			CreatedAt:  d.CreatedAt.UnixMilli(),
+			UpdatedAt:  d.UpdatedAt.UnixMilli(),
+		}
+		if d.Status == dbModel.WebhookDeliveryPending {
+			resp[i].NextAttemptAt = d.NextAttemptAt.UnixMilli()
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewApiDataResp(gin.H{
+		"total": total,
+		"list":  resp,
+	}))
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -17,19 +20,32 @@ import (
 	"github.com/synctv-org/synctv/server/middlewares"
 	"github.com/synctv-org/synctv/server/model"
 	"github.com/synctv-org/synctv/utils"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
-const maxInterval = 10
-
 func NewWebSocketHandler(wss *utils.WebSocket) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		token := ctx.GetHeader("Sec-WebSocket-Protocol")
+		protocol, token := parseWSProtocols(ctx.GetHeader("Sec-WebSocket-Protocol"))
 		userE, roomE, err := middlewares.AuthRoom(token)
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, model.NewApiErrorResp(err))
 			return
 		}
+		// the last sequence the client has seen, to resume the session after reconnecting
+		var seq uint64
+		if s := ctx.Query("seq"); s != "" {
+			seq, err = strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(errors.New("seq must be a number")))
+				return
+			}
+		}
+		conf, err := slowConsumerConf(ctx)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewApiErrorResp(err))
+			return
+		}
 		user := userE.Value()
 		room := roomE.Value()
 		entry := log.WithFields(log.Fields{
@@ -40,25 +56,86 @@ func NewWebSocketHandler(wss *utils.WebSocket) gin.HandlerFunc {
 			"uro": user.Role.String(),
 		})
 
-		_ = wss.Server(ctx.Writer, ctx.Request, []string{token}, NewWSMessageHandler(user, room, entry))
+		// old clients only send the token, echo it back as before
+		subprotocol := token
+		if protocol != "" {
+			subprotocol = protocol
+		}
+		_ = wss.Server(ctx.Writer, ctx.Request, []string{subprotocol}, NewWSMessageHandler(user, room, seq, protocol == WSProtocolJSON, entry, conf...))
+	}
+}
+
+const (
+	WSProtocolProtobuf = "protobuf"
+	WSProtocolJSON     = "json"
+)
+
+// parseWSProtocols splits the Sec-WebSocket-Protocol header
+// into the encoding the client asks for and the token.
+func parseWSProtocols(header string) (protocol, token string) {
+	for _, p := range strings.Split(header, ",") {
+		switch p = strings.TrimSpace(p); p {
+		case WSProtocolProtobuf, WSProtocolJSON:
+			protocol = p
+		default:
+			token = p
+		}
 	}
+	return
 }
 
-func NewWSMessageHandler(u *op.User, r *op.Room, l *logrus.Entry) func(c *websocket.Conn) error {
+// slowConsumerConf reads the slow consumer policy the client asks for,
+// e.g. ?slow_consumer=disconnect&max_dropped=16, the configured one is used by default.
+func slowConsumerConf(ctx *gin.Context) ([]op.ClientConf, error) {
+	p := ctx.Query("slow_consumer")
+	if p == "" {
+		return nil, nil
+	}
+	policy, err := op.ParseSlowConsumerPolicy(p)
+	if err != nil {
+		return nil, err
+	}
+	var maxDropped uint64
+	if s := ctx.Query("max_dropped"); s != "" {
+		maxDropped, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, errors.New("max_dropped must be a number")
+		}
+	}
+	return []op.ClientConf{op.WithSlowConsumerPolicy(policy, maxDropped)}, nil
+}
+
+func NewWSMessageHandler(u *op.User, r *op.Room, seq uint64, json bool, l *logrus.Entry, conf ...op.ClientConf) func(c *websocket.Conn) error {
 	return func(c *websocket.Conn) error {
-		client, err := r.NewClient(u, c)
+		if json {
+			conf = append(conf, op.WithJSONEncoding())
+		}
+		var (
+			client  *op.Client
+			resumed bool
+			err     error
+		)
+		if seq == 0 {
+			client, err = r.NewClient(u, c, conf...)
+		} else {
+			client, resumed, err = r.ResumeClient(u, c, seq, conf...)
+		}
 		if err != nil {
 			log.Errorf("ws: register client error: %v", err)
-			wc, err2 := c.NextWriter(websocket.BinaryMessage)
+			em := &pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: err.Error(),
+			}
+			var m op.Message = em
+			if json {
+				m = &pb.JSONElementMessage{ElementMessage: em}
+			}
+			wc, err2 := c.NextWriter(m.MessageType())
 			if err2 != nil {
 				return err2
 			}
 			defer wc.Close()
-			em := pb.ElementMessage{
-				Type:  pb.ElementMessageType_ERROR,
-				Error: err.Error(),
-			}
-			return em.Encode(wc)
+			return m.Encode(wc)
 		}
 		l.Info("ws: connected")
 		defer func() {
@@ -67,6 +144,13 @@ func NewWSMessageHandler(u *op.User, r *op.Room, l *logrus.Entry) func(c *websoc
 			l.Info("ws: disconnected")
 		}()
 		go handleReaderMessage(client, l)
+		if !resumed {
+			go func() {
+				if err := client.SendChatHistory(); err != nil {
+					l.Errorf("ws: send chat history error: %v", err)
+				}
+			}()
+		}
 		return handleWriterMessage(client, l)
 	}
 }
@@ -92,6 +176,9 @@ func handleWriterMessage(c *op.Client, l *logrus.Entry) error {
 	return nil
 }
 
+// text frames are protojson, be lenient with the fields written by hand
+var jsonUnmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
 func handleReaderMessage(c *op.Client, l *logrus.Entry) error {
 	defer func() {
 		c.Close()
@@ -106,7 +193,7 @@ func handleReaderMessage(c *op.Client, l *logrus.Entry) error {
 			return err
 		}
 		l.Debugf("ws: receive message type: %d", t)
-		if t != websocket.BinaryMessage {
+		if t != websocket.BinaryMessage && t != websocket.TextMessage {
 			l.Errorf("ws: receive unknown message type: %d", t)
 			continue
 		}
@@ -123,7 +210,12 @@ func handleReaderMessage(c *op.Client, l *logrus.Entry) error {
 			continue
 		}
 		var msg pb.ElementMessage
-		if err := proto.Unmarshal(data, &msg); err != nil {
+		if t == websocket.TextMessage {
+			err = jsonUnmarshalOptions.Unmarshal(data, &msg)
+		} else {
+			err = proto.Unmarshal(data, &msg)
+		}
+		if err != nil {
 			l.Errorf("ws: unmarshal message error: %v", err)
 			if err := c.Send(&pb.ElementMessage{
 				Type:  pb.ElementMessageType_ERROR,
@@ -145,19 +237,55 @@ func handleReaderMessage(c *op.Client, l *logrus.Entry) error {
 
 const MaxChatMessageLength = 4096
 
-func handleElementMsg(cli *op.Client, msg *pb.ElementMessage) error {
-	var timeDiff float64
-	if msg.Time != 0 {
-		timeDiff = time.Since(time.UnixMilli(msg.Time)).Seconds()
-	} else {
-		timeDiff = 0.0
+const (
+	maxTimeDiff       = 1.5
+	maxSyncedTimeDiff = 5
+)
+
+// the time of the message is the client clock,
+// convert it to the server clock when the client has synced its offset
+func getTimeDiff(cli *op.Client, t int64) float64 {
+	if t == 0 {
+		return 0
+	}
+	offset, synced := cli.TimeOffset()
+	timeDiff := time.Since(time.UnixMilli(t).Add(-offset)).Seconds()
+	limit := float64(maxTimeDiff)
+	if synced {
+		limit = maxSyncedTimeDiff
 	}
 	if timeDiff < 0 {
 		timeDiff = 0
-	} else if timeDiff > 1.5 {
-		timeDiff = 1.5
+	} else if timeDiff > limit {
+		timeDiff = limit
 	}
+	return timeDiff
+}
+
+func handleElementMsg(cli *op.Client, msg *pb.ElementMessage) error {
+	timeDiff := getTimeDiff(cli, msg.Time)
 	switch msg.Type {
+	case pb.ElementMessageType_TIME_SYNC:
+		receiveTime := time.Now()
+		req := msg.GetTimeSyncReq()
+		if req == nil {
+			return cli.Send(&pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: "time sync request is nil",
+			})
+		}
+		if req.Rtt > 0 {
+			cli.SetTimeOffset(time.Duration(req.Offset)*time.Millisecond, time.Duration(req.Rtt)*time.Millisecond)
+		}
+		return cli.Send(&pb.ElementMessage{
+			Type: pb.ElementMessageType_TIME_SYNC,
+			Time: time.Now().UnixMilli(),
+			TimeSyncResp: &pb.TimeSyncResp{
+				ClientTime:        req.ClientTime,
+				ServerReceiveTime: receiveTime.UnixMilli(),
+				ServerSendTime:    time.Now().UnixMilli(),
+			},
+		})
 	case pb.ElementMessageType_CHAT_MESSAGE:
 		message := msg.GetChatReq()
 		if len(message) > MaxChatMessageLength {
@@ -167,13 +295,85 @@ func handleElementMsg(cli *op.Client, msg *pb.ElementMessage) error {
 			})
 		}
 		err := cli.SendChatMessage(message)
-		if err != nil && errors.Is(err, dbModel.ErrNoPermission) {
+		if err != nil && (errors.Is(err, dbModel.ErrNoPermission) || errors.Is(err, op.ErrChatModerated)) {
 			return cli.Send(&pb.ElementMessage{
 				Type:  pb.ElementMessageType_ERROR,
 				Error: fmt.Sprintf("send chat message error: %v", err),
 			})
 		}
 		return err
+	case pb.ElementMessageType_WHISPER:
+		w := msg.GetWhisper()
+		if w.GetTarget().GetUserid() == "" || w.GetMessage() == "" {
+			return cli.Send(&pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: "whisper target or message is empty",
+			})
+		}
+		if len(w.Message) > MaxChatMessageLength {
+			return cli.Send(&pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: "message too long",
+			})
+		}
+		err := cli.SendWhisper(w.Target.Userid, w.Message)
+		if err != nil {
+			// the target is not a member and so on, the connection is fine
+			return cli.Send(&pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: fmt.Sprintf("send whisper error: %v", err),
+			})
+		}
+		return nil
+	case pb.ElementMessageType_POLL_VOTE:
+		v := msg.GetPollVote()
+		if v == nil {
+			return cli.Send(&pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: "poll vote is nil",
+			})
+		}
+		if err := cli.VotePoll(v.PollId, int(v.Option)); err != nil {
+			// a late vote and so on, the connection is fine
+			return cli.Send(&pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: fmt.Sprintf("vote error: %v", err),
+			})
+		}
+		return nil
+	case pb.ElementMessageType_ENDED:
+		if err := cli.ReportEnded(msg.EndedMovieId); err != nil {
+			return cli.Send(&pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: fmt.Sprintf("advance playlist error: %v", err),
+			})
+		}
+		return nil
+	case pb.ElementMessageType_DANMAKU:
+		d := msg.GetDanmaku()
+		if d.GetContent() == "" {
+			return cli.Send(&pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: "danmaku is empty",
+			})
+		}
+		if len(d.Content) > MaxDanmakuLength {
+			return cli.Send(&pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: "danmaku too long",
+			})
+		}
+		err := cli.SendDanmaku(d.MovieId, d.Time, d.Content, d.Color, dbModel.DanmakuMode(d.Mode))
+		if err != nil && (errors.Is(err, dbModel.ErrNoPermission) ||
+			errors.Is(err, op.ErrChatModerated) ||
+			errors.Is(err, op.ErrNoCurrentMovie) ||
+			errors.Is(err, op.ErrCurrentMovieChanged)) {
+			return cli.Send(&pb.ElementMessage{
+				Type:  pb.ElementMessageType_ERROR,
+				Error: fmt.Sprintf("send danmaku error: %v", err),
+			})
+		}
+		return err
 	case pb.ElementMessageType_PLAY,
 		pb.ElementMessageType_PAUSE,
 		pb.ElementMessageType_CHANGE_RATE:
@@ -220,6 +420,9 @@ func handleElementMsg(cli *op.Client, msg *pb.ElementMessage) error {
 				},
 			},
 		}, op.WithIgnoreClient(cli))
+	case pb.ElementMessageType_BUFFERING_START,
+		pb.ElementMessageType_BUFFERING_END:
+		return cli.SetBuffering(msg.Type == pb.ElementMessageType_BUFFERING_START)
 	case pb.ElementMessageType_SYNC_MOVIE_STATUS:
 		status := cli.Room().Current().Status
 		return cli.Send(&pb.ElementMessage{
@@ -254,29 +457,81 @@ func handleElementMsg(cli *op.Client, msg *pb.ElementMessage) error {
 		}
 		status := current.Status
 		cliStatus := msg.CheckReq.Status
-		if status.Seek+maxInterval < cliStatus.Seek+timeDiff {
-			return cli.Send(&pb.ElementMessage{
-				Type: pb.ElementMessageType_TOO_FAST,
-				MovieStatusChanged: &pb.MovieStatusChanged{
-					Status: &pb.MovieStatus{
-						Playing: status.Playing,
-						Seek:    status.Seek,
-						Rate:    status.Rate,
-					},
-				},
-			})
-		} else if status.Seek-maxInterval > cliStatus.Seek+timeDiff {
-			return cli.Send(&pb.ElementMessage{
-				Type: pb.ElementMessageType_TOO_SLOW,
-				MovieStatusChanged: &pb.MovieStatusChanged{
-					Status: &pb.MovieStatus{
-						Playing: status.Playing,
-						Seek:    status.Seek,
-						Rate:    status.Rate,
-					},
+		var drift float64
+		if !current.IsLive {
+			drift = cliStatus.Seek + timeDiff - status.Seek
+		}
+		// BUFFERING_START and BUFFERING_END are the only source of the buffering
+		pre, _ := cli.CheckStatus()
+		if err := cli.SetCheckStatus(op.ClientStatus{
+			Playing:   cliStatus.Playing,
+			Seek:      cliStatus.Seek,
+			Buffering: pre.Buffering,
+			Drift:     drift,
+		}); err != nil {
+			return err
+		}
+		if current.IsLive {
+			return nil
+		}
+		return checkDrift(cli, &status, drift)
+	}
+	return nil
+}
+
+// the playback rate is changed by at most this fraction of the room rate when nudging
+const maxNudgeRate = 0.1
+
+func checkDrift(cli *op.Client, status *op.Status, drift float64) error {
+	settings := cli.Room().Settings
+	absDrift := math.Abs(drift)
+	switch {
+	case absDrift > settings.SyncSeekThreshold,
+		// a paused client can not catch up by changing the rate
+		!status.Playing && absDrift > settings.SyncNudgeThreshold:
+		t := pb.ElementMessageType_TOO_SLOW
+		if drift > 0 {
+			t = pb.ElementMessageType_TOO_FAST
+		}
+		return cli.Send(&pb.ElementMessage{
+			Type: t,
+			MovieStatusChanged: &pb.MovieStatusChanged{
+				Status: &pb.MovieStatus{
+					Playing: status.Playing,
+					Seek:    status.Seek,
+					Rate:    status.Rate,
 				},
-			})
+			},
+			DriftCorrection: &pb.DriftCorrection{
+				Drift: drift,
+				Rate:  status.Rate,
+			},
+		})
+	case absDrift > settings.SyncNudgeThreshold:
+		nudge := status.Rate * maxNudgeRate
+		if nudge <= 0 {
+			return nil
 		}
+		rate := status.Rate + nudge
+		if drift > 0 {
+			rate = status.Rate - nudge
+		}
+		return cli.Send(&pb.ElementMessage{
+			Type: pb.ElementMessageType_DRIFT_CORRECTION,
+			MovieStatusChanged: &pb.MovieStatusChanged{
+				Status: &pb.MovieStatus{
+					Playing: status.Playing,
+					Seek:    status.Seek,
+					Rate:    status.Rate,
+				},
+			},
+			DriftCorrection: &pb.DriftCorrection{
+				Drift: drift,
+				Rate:  rate,
+				// seconds to play at the nudged rate before going back to the room rate
+				Duration: absDrift / nudge,
+			},
+		})
 	}
 	return nil
 }
@@ -301,7 +301,7 @@ func AuthRoomAdminMiddleware(ctx *gin.Context) {
 	room := ctx.MustGet("room").(*synccache.Entry[*op.Room]).Value()
 	user := ctx.MustGet("user").(*synccache.Entry[*op.User]).Value()
 
-	if !user.IsRoomAdmin(room) {
+	if !user.IsRoomAdmin(room) && !room.IsRoleAdmin(user.ID) {
 		ctx.AbortWithStatusJSON(http.StatusForbidden, model.NewApiErrorStringResp("user has no permission"))
 		return
 	}
@@ -190,3 +190,8 @@ func (ster *SendTestEmailReq) Validate() error {
 func (ster *SendTestEmailReq) Decode(ctx *gin.Context) error {
 	return json.NewDecoder(ctx.Request.Body).Decode(ster)
 }
+
+type WebSocketStatsResp struct {
+	DroppedMessages uint64 `json:"droppedMessages"`
+	EvictedClients  uint64 `json:"evictedClients"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import dbModel "github.com/synctv-org/synctv/internal/model"
+
+type AuditLogResp struct {
+	ID         uint64              `json:"id"`
+	Action     dbModel.AuditAction `json:"action"`
+	ActorID    string              `json:"actorId"`
+	ActorName  string              `json:"actorName"`
+	TargetID   string              `json:"targetId"`
+	TargetName string              `json:"targetName"`
+	// json, empty if there is no value
+	OldValue  string `json:"oldValue"`
+	NewValue  string `json:"newValue"`
+	CreatedAt int64  `json:"createdAt"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+)
+
+type ImportRoomReq struct {
+	// defaults to the name in the bundle
+	RoomName string              `json:"roomName"`
+	Password string              `json:"password"`
+	Bundle   *dbModel.RoomBundle `json:"bundle"`
+}
+
+func (i *ImportRoomReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(i)
+}
+
+func (i *ImportRoomReq) Validate() error {
+	if i.Bundle == nil {
+		return errors.New("bundle is required")
+	}
+	if i.RoomName == "" {
+		i.RoomName = i.Bundle.Room.Name
+	}
+	return (&CreateRoomReq{
+		RoomName: i.RoomName,
+		Password: i.Password,
+	}).Validate()
+}
@@ -0,0 +1,12 @@
+package model
+
+type ChatMessageResp struct {
+	ID       uint64 `json:"id"`
+	SenderID string `json:"senderId"`
+	Username string `json:"username"`
+	Message  string `json:"message"`
+	Time     int64  `json:"time"`
+	// set if the message is a whisper
+	ReceiverID   string `json:"receiverId,omitempty"`
+	ReceiverName string `json:"receiverName,omitempty"`
+}
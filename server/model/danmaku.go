@@ -0,0 +1,15 @@
+package model
+
+type DanmakuResp struct {
+	ID       uint64  `json:"id"`
+	SenderID string  `json:"senderId"`
+	Username string  `json:"username"`
+	Time     float64 `json:"time"`
+	Content  string  `json:"content"`
+	Color    uint32  `json:"color"`
+	Mode     uint32  `json:"mode"`
+}
+
+type ImportDanmakuResp struct {
+	Count int `json:"count"`
+}
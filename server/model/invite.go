@@ -0,0 +1,56 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+)
+
+type CreateInviteReq struct {
+	Permissions dbModel.RoomMemberPermission `json:"permissions"`
+	// 0 means single-use
+	MaxUses uint64 `json:"maxUses"`
+	// unix milliseconds
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+func (c *CreateInviteReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(c)
+}
+
+func (c *CreateInviteReq) Validate() error {
+	if c.ExpiresAt <= 0 {
+		return errors.New("expire time is required")
+	}
+	return nil
+}
+
+type RedeemInviteReq struct {
+	Token string `json:"token"`
+}
+
+func (r *RedeemInviteReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func (r *RedeemInviteReq) Validate() error {
+	if r.Token == "" || len(r.Token) > 32 {
+		return errors.New("invalid invite token")
+	}
+	return nil
+}
+
+type InviteResp struct {
+	ID          string                       `json:"id"`
+	Token       string                       `json:"token"`
+	Permissions dbModel.RoomMemberPermission `json:"permissions"`
+	MaxUses     uint64                       `json:"maxUses"`
+	Uses        uint64                       `json:"uses"`
+	ExpiresAt   int64                        `json:"expiresAt"`
+	CreatedAt   int64                        `json:"createdAt"`
+	CreatorID   string                       `json:"creatorId"`
+	CreatorName string                       `json:"creatorName"`
+	Usable      bool                         `json:"usable"`
+}
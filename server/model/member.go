@@ -16,9 +16,13 @@ type RoomMembersResp struct {
 	RoomID           string                       `json:"roomId"`
 	Permissions      dbModel.RoomMemberPermission `json:"permissions"`
 	AdminPermissions dbModel.RoomAdminPermission  `json:"adminPermissions"`
+	MutedUntil       int64                        `json:"mutedUntil"`
+	// ids of the custom roles held by the member
+	Roles []string `json:"roles"`
 }
 
 type RoomApproveMemberReq = UserIDReq
+type RoomSetHostReq = UserIDReq
 type RoomBanMemberReq = UserIDReq
 type RoomUnbanMemberReq = UserIDReq
 
@@ -38,6 +42,8 @@ type RoomMeResp struct {
 	Role             dbModel.RoomMemberRole       `json:"role"`
 	Permissions      dbModel.RoomMemberPermission `json:"permissions"`
 	AdminPermissions dbModel.RoomAdminPermission  `json:"adminPermissions"`
+	// ids of the custom roles held by the member, the permissions include theirs
+	Roles []string `json:"roles"`
 }
 
 type RoomSetAdminReq struct {
@@ -66,3 +72,19 @@ type RoomSetAdminPermissionsReq struct {
 func (r *RoomSetAdminPermissionsReq) Decode(ctx *gin.Context) error {
 	return json.NewDecoder(ctx.Request.Body).Decode(r)
 }
+
+type RoomHostResp struct {
+	UserID   string `json:"userId"`
+	Username string `json:"username"`
+}
+
+type RoomPresenceResp struct {
+	UserID    string  `json:"userId"`
+	Username  string  `json:"username"`
+	Devices   int     `json:"devices"`
+	Playing   bool    `json:"playing"`
+	Seek      float64 `json:"seek"`
+	Buffering bool    `json:"buffering"`
+	Drift     float64 `json:"drift"`
+	CheckedAt int64   `json:"checkedAt"`
+}
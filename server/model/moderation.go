@@ -0,0 +1,64 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+)
+
+type RoomMuteMemberReq struct {
+	UserIDReq
+	// seconds
+	Duration int64 `json:"duration"`
+}
+
+func (r *RoomMuteMemberReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func (r *RoomMuteMemberReq) Validate() error {
+	if err := r.UserIDReq.Validate(); err != nil {
+		return err
+	}
+	if r.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	return nil
+}
+
+type RoomUnmuteMemberReq = UserIDReq
+
+type ChatMessageIDReq struct {
+	ID uint64 `json:"id"`
+}
+
+func (c *ChatMessageIDReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(c)
+}
+
+func (c *ChatMessageIDReq) Validate() error {
+	if c.ID == 0 {
+		return errors.New("id is required")
+	}
+	return nil
+}
+
+type ChatModerationReq struct {
+	// seconds
+	SlowModeInterval int64  `json:"slowModeInterval"`
+	Blocklist        string `json:"blocklist"`
+}
+
+func (c *ChatModerationReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(c)
+}
+
+func (c *ChatModerationReq) Validate() error {
+	if c.SlowModeInterval < 0 {
+		return errors.New("slow mode interval cannot be negative")
+	}
+	return nil
+}
+
+type ChatModerationResp = ChatModerationReq
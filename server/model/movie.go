@@ -14,6 +14,7 @@ var (
 	ErrUrlTooLong  = errors.New("url too long")
 	ErrEmptyName   = errors.New("empty name")
 	ErrTypeTooLong = errors.New("type too long")
+	ErrDuration    = errors.New("duration must not be negative")
 
 	ErrId = errors.New("id must be greater than 0")
 
@@ -42,6 +43,12 @@ func (p *PushMovieReq) Validate() error {
 		return ErrTypeTooLong
 	}
 
+	if p.Duration < 0 {
+		return ErrDuration
+	} else if p.Live {
+		p.Duration = 0
+	}
+
 	return nil
 }
 
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/synctv-org/synctv/internal/op"
+)
+
+type StartPollReq struct {
+	Kind     op.PollKind `json:"kind"`
+	Question string      `json:"question"`
+	Options  []string    `json:"options"`
+	// zero means the room settings
+	Quorum float64 `json:"quorum"`
+	// seconds, zero means the room settings
+	Timeout int64 `json:"timeout"`
+}
+
+func (s *StartPollReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(s)
+}
+
+func (s *StartPollReq) Validate() error {
+	switch s.Kind {
+	case op.PollKindSkip, op.PollKindChoice:
+	default:
+		return errors.New("unknown poll kind")
+	}
+	if len(s.Question) > 256 {
+		return errors.New("question is too long")
+	}
+	for _, o := range s.Options {
+		if o == "" || len(o) > 128 {
+			return errors.New("option is empty or too long")
+		}
+	}
+	if s.Quorum < 0 || s.Quorum > 1 {
+		return errors.New("quorum must be between 0 and 1")
+	}
+	if s.Timeout < 0 {
+		return errors.New("timeout cannot be negative")
+	}
+	return nil
+}
+
+type PollResp struct {
+	ID          string      `json:"id"`
+	Kind        op.PollKind `json:"kind"`
+	Question    string      `json:"question"`
+	Options     []string    `json:"options"`
+	Votes       []int64     `json:"votes"`
+	Voters      int64       `json:"voters"`
+	Quorum      float64     `json:"quorum"`
+	ExpiresAt   int64       `json:"expiresAt"`
+	CreatorID   string      `json:"creatorId"`
+	CreatorName string      `json:"creatorName"`
+	MovieID     string      `json:"movieId,omitempty"`
+	Result      int32       `json:"result"`
+	Ended       bool        `json:"ended"`
+}
@@ -28,7 +28,9 @@ func (f FormatEmptyPasswordError) Error() string {
 type CreateRoomReq struct {
 	RoomName string `json:"roomName"`
 	Password string `json:"password"`
-	Settings struct {
+	// create the room from a template of the user
+	TemplateID string `json:"templateId"`
+	Settings   struct {
 		Hidden bool `json:"hidden"`
 	} `json:"settings"`
 }
@@ -54,6 +56,10 @@ func (c *CreateRoomReq) Validate() error {
 		}
 	}
 
+	if c.TemplateID != "" && len(c.TemplateID) != 32 {
+		return ErrId
+	}
+
 	return nil
 }
 
@@ -131,3 +137,12 @@ func (s *SetRoomSettingReq) Decode(ctx *gin.Context) error {
 func (s *SetRoomSettingReq) Validate() error {
 	return nil
 }
+
+type RoomClientSyncResp struct {
+	UserID    string  `json:"userId"`
+	Username  string  `json:"username"`
+	Drift     float64 `json:"drift"`
+	RTT       int64   `json:"rtt"`
+	CheckedAt int64   `json:"checkedAt"`
+	Dropped   uint64  `json:"dropped"`
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+)
+
+type RoomRoleReq struct {
+	Name             string                       `json:"name"`
+	Permissions      dbModel.RoomMemberPermission `json:"permissions"`
+	AdminPermissions dbModel.RoomAdminPermission  `json:"adminPermissions"`
+}
+
+func (r *RoomRoleReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func (r *RoomRoleReq) Validate() error {
+	if r.Name == "" {
+		return errors.New("role name is required")
+	}
+	if len(r.Name) > 32 {
+		return errors.New("role name is too long")
+	}
+	return nil
+}
+
+type UpdateRoomRoleReq struct {
+	ID string `json:"id"`
+	RoomRoleReq
+}
+
+func (r *UpdateRoomRoleReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func (r *UpdateRoomRoleReq) Validate() error {
+	if len(r.ID) != 32 {
+		return ErrInvalidID
+	}
+	return r.RoomRoleReq.Validate()
+}
+
+type SetMemberRolesReq struct {
+	UserIDReq
+	Roles []string `json:"roles"`
+}
+
+func (r *SetMemberRolesReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func (r *SetMemberRolesReq) Validate() error {
+	if err := r.UserIDReq.Validate(); err != nil {
+		return err
+	}
+	for _, id := range r.Roles {
+		if len(id) != 32 {
+			return ErrInvalidID
+		}
+	}
+	return nil
+}
+
+type RoomRoleResp struct {
+	ID               string                       `json:"id"`
+	Name             string                       `json:"name"`
+	Permissions      dbModel.RoomMemberPermission `json:"permissions"`
+	AdminPermissions dbModel.RoomAdminPermission  `json:"adminPermissions"`
+	CreatedAt        int64                        `json:"createdAt"`
+	UpdatedAt        int64                        `json:"updatedAt"`
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+)
+
+type ScheduleMovieReq struct {
+	MovieID string `json:"movieId"`
+	Title   string `json:"title"`
+	// unix milliseconds
+	StartAt int64 `json:"startAt"`
+}
+
+func (s *ScheduleMovieReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(s)
+}
+
+func (s *ScheduleMovieReq) Validate() error {
+	if len(s.MovieID) != 32 {
+		return ErrId
+	}
+	if len(s.Title) > 256 {
+		return errors.New("title is too long")
+	}
+	if s.StartAt <= 0 {
+		return errors.New("start time is required")
+	}
+	return nil
+}
+
+type ScheduleResp struct {
+	ID          string `json:"id"`
+	MovieID     string `json:"movieId"`
+	MovieName   string `json:"movieName"`
+	Title       string `json:"title"`
+	StartAt     int64  `json:"startAt"`
+	CreatorID   string `json:"creatorId"`
+	CreatorName string `json:"creatorName"`
+}
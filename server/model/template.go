@@ -0,0 +1,76 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+)
+
+type CreateTemplateReq struct {
+	Name string `json:"name"`
+	// defaults to the default room settings
+	Settings    *dbModel.RoomSettings        `json:"settings"`
+	Permissions dbModel.RoomMemberPermission `json:"permissions"`
+	Movies      PushMoviesReq                `json:"movies"`
+}
+
+func (c *CreateTemplateReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(c)
+}
+
+func (c *CreateTemplateReq) Validate() error {
+	if err := validateTemplateName(c.Name); err != nil {
+		return err
+	}
+	return c.Movies.Validate()
+}
+
+type SaveRoomTemplateReq struct {
+	Name       string `json:"name"`
+	WithMovies bool   `json:"withMovies"`
+}
+
+func (s *SaveRoomTemplateReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(s)
+}
+
+func (s *SaveRoomTemplateReq) Validate() error {
+	return validateTemplateName(s.Name)
+}
+
+func validateTemplateName(name string) error {
+	if name == "" {
+		return errors.New("template name is empty")
+	} else if len(name) > 64 {
+		return errors.New("template name is too long")
+	}
+	return nil
+}
+
+type CloneRoomReq struct {
+	RoomName string `json:"roomName"`
+	Password string `json:"password"`
+}
+
+func (c *CloneRoomReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(c)
+}
+
+func (c *CloneRoomReq) Validate() error {
+	return (&CreateRoomReq{
+		RoomName: c.RoomName,
+		Password: c.Password,
+	}).Validate()
+}
+
+type TemplateResp struct {
+	ID          string                       `json:"id"`
+	Name        string                       `json:"name"`
+	Settings    *dbModel.RoomSettings        `json:"settings"`
+	Permissions dbModel.RoomMemberPermission `json:"permissions"`
+	Movies      []*dbModel.BaseMovie         `json:"movies"`
+	CreatedAt   int64                        `json:"createdAt"`
+	UpdatedAt   int64                        `json:"updatedAt"`
+}
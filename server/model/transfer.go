@@ -0,0 +1,35 @@
+package model
+
+import (
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+)
+
+type OfferRoomTransferReq = UserIDReq
+
+type AdminTransferRoomReq struct {
+	RoomID string `json:"roomId"`
+	UserID string `json:"userId"`
+}
+
+func (r *AdminTransferRoomReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func (r *AdminTransferRoomReq) Validate() error {
+	if len(r.RoomID) != 32 || len(r.UserID) != 32 {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+type RoomTransferResp struct {
+	RoomID    string `json:"roomId"`
+	RoomName  string `json:"roomName"`
+	FromID    string `json:"fromId"`
+	FromName  string `json:"fromName"`
+	ToID      string `json:"toId"`
+	ToName    string `json:"toName"`
+	CreatedAt int64  `json:"createdAt"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	dbModel "github.com/synctv-org/synctv/internal/model"
+)
+
+type AddWebhookReq struct {
+	URL string `json:"url"`
+	// empty means all events
+	Events []dbModel.WebhookEvent `json:"events"`
+}
+
+func (a *AddWebhookReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(a)
+}
+
+func (a *AddWebhookReq) Validate() error {
+	if a.URL == "" {
+		return errors.New("url is required")
+	}
+	if len(a.URL) > 1024 {
+		return ErrUrlTooLong
+	}
+	return nil
+}
+
+type WebhookResp struct {
+	ID        string                 `json:"id"`
+	URL       string                 `json:"url"`
+	Events    []dbModel.WebhookEvent `json:"events"`
+	CreatorID string                 `json:"creatorId"`
+	CreatedAt int64                  `json:"createdAt"`
+	// only returned when the webhook is created
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhookDeliveryResp struct {
+	ID            uint64                        `json:"id"`
+	Event         dbModel.WebhookEvent          `json:"event"`
+	Payload       string                        `json:"payload"`
+	Status        dbModel.WebhookDeliveryStatus `json:"status"`
+	Attempts      int                           `json:"attempts"`
+	StatusCode    int                           `json:"statusCode"`
+	Error         string                        `json:"error"`
+	CreatedAt     int64                         `json:"createdAt"`
+	UpdatedAt     int64                         `json:"updatedAt"`
+	NextAttemptAt int64                         `json:"nextAttemptAt,omitempty"`
+}
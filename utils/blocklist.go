@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Blocklist matches text against words and regular expressions,
+// an entry wrapped in slashes such as /fo+/ is a regular expression,
+// the others are words matched case-insensitively.
+type Blocklist struct {
+	words   []string
+	regexps []*regexp.Regexp
+}
+
+// ParseBlocklist parses one entry per line, blank lines are skipped
+func ParseBlocklist(s string) (*Blocklist, error) {
+	b := &Blocklist{}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case len(line) > 2 && strings.HasPrefix(line, "/") && strings.HasSuffix(line, "/"):
+			re, err := regexp.Compile(line[1 : len(line)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid blocklist entry %s: %w", line, err)
+			}
+			b.regexps = append(b.regexps, re)
+		default:
+			b.words = append(b.words, strings.ToLower(line))
+		}
+	}
+	return b, nil
+}
+
+func (b *Blocklist) Match(text string) bool {
+	if b == nil {
+		return false
+	}
+	if len(b.words) != 0 {
+		lower := strings.ToLower(text)
+		for _, w := range b.words {
+			if strings.Contains(lower, w) {
+				return true
+			}
+		}
+	}
+	for _, re := range b.regexps {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
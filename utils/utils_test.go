@@ -128,3 +128,24 @@ func TestTruncateByRune(t *testing.T) {
 		t.Errorf("TruncateByRune() = %v, want %v", utils.TruncateByRune(name, 10), "abcd测试")
 	}
 }
+
+func TestBlocklist(t *testing.T) {
+	b, err := utils.ParseBlocklist("spam\n\n/fo+bar/\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]bool{
+		"no SPAM here": true,
+		"foooobar":     true,
+		"fbar":         false,
+		"hello":        false,
+	}
+	for text, want := range tests {
+		if got := b.Match(text); got != want {
+			t.Errorf("Match(%q) = %v, want %v", text, got, want)
+		}
+	}
+	if _, err := utils.ParseBlocklist("/(/"); err == nil {
+		t.Error("invalid regexp should fail")
+	}
+}